@@ -2,6 +2,8 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -9,9 +11,12 @@ import (
 	"strings"
 
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/auth"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/debug"
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/fetcher"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/kredensial"
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/model"
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/parser"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/profil"
 )
 
 var (
@@ -21,15 +26,30 @@ var (
 	indentJSON    = flag.Bool("indent", false, "gunakan indentasi untuk output JSON")
 	tanpaContoh   = flag.Bool("tanpa-contoh", false, "jangan tampilkan contoh penggunaan")
 	tanpaTerkait  = flag.Bool("tanpa-terkait", false, "jangan tampilkan kata terkait")
+	tanpaLampiran = flag.Bool("tanpa-lampiran", false, "jangan tampilkan lampiran entri")
 	tanpaCache    = flag.Bool("tanpa-cache", false, "langsung request ke KBBI tanpa menggunakan cache")
 	nonpengguna   = flag.Bool("nonpengguna", false, "nonaktifkan fitur khusus pengguna")
 
+	// Flag untuk debug
+	debugHTML   = flag.String("debug-html", "", "direktori untuk menyimpan HTML mentah saat parsing gagal")
+	debugSelalu = flag.Bool("debug-selalu", false, "selalu simpan HTML mentah (hanya dengan --debug-html)")
+	ketat       = flag.Bool("ketat", false, "gagal jika struktur halaman KBBI tidak dikenali parser")
+	diagnostik  = flag.Bool("diagnostik", false, "tampilkan peringatan parser ke stderr")
+
 	// Flag untuk autentikasi
-	email        = flag.String("email", "", "alamat email untuk autentikasi KBBI")
-	sandi        = flag.String("sandi", "", "kata sandi untuk autentikasi KBBI")
-	lokasiKuki   = flag.String("lokasi-kuki", "", "lokasi file kuki untuk autentikasi")
-	autentikasi  = flag.Bool("autentikasi", false, "lakukan autentikasi dengan email dan sandi")
-	hapusKuki = flag.Bool("bersihkan-kuki", false, "hapus kuki yang tersimpan")
+	email              = flag.String("email", "", "alamat email untuk autentikasi KBBI")
+	sandi              = flag.String("sandi", "", "kata sandi untuk autentikasi KBBI (usang, terlihat di daftar proses)")
+	lokasiKuki         = flag.String("lokasi-kuki", "", "lokasi file kuki untuk autentikasi")
+	autentikasi        = flag.Bool("autentikasi", false, "lakukan autentikasi dengan email dan sandi")
+	hapusKuki          = flag.Bool("bersihkan-kuki", false, "hapus kuki yang tersimpan")
+	enkripsiKuki       = flag.Bool("enkripsi-kuki", false, "enkripsi file kuki dengan frasa sandi saat autentikasi")
+	fileFrasaSandi     = flag.String("file-frasa-sandi", "", "lokasi file berisi frasa sandi kuki terenkripsi")
+	fileKredensial     = flag.String("file-kredensial", "", "lokasi file JSON berisi email dan sandi KBBI")
+	pembantuKredensial = flag.String("pembantu-kredensial", "", "perintah eksternal yang menyediakan email dan sandi KBBI")
+
+	// Flag untuk profil
+	namaProfil   = flag.String("profil", "", "nama profil akun di ~/.kbbi/profil/<nama>")
+	cacheSendiri = flag.Bool("cache-sendiri", false, "gunakan cache terpisah untuk profil (hanya dengan \"profil buat\")")
 
 	// Flag bantuan
 	bantuan = flag.Bool("bantuan", false, "tampilkan bantuan penggunaan")
@@ -37,6 +57,14 @@ var (
 	version = flag.Bool("version", false, "tampilkan versi aplikasi")
 )
 
+var (
+	// profilAktif adalah profil dari --profil, nil jika tidak memakai profil
+	profilAktif *profil.Profil
+
+	// lokasiCache adalah acuan direktori cache (lihat cache.BaruManagerCache)
+	lokasiCache string
+)
+
 const (
 	AppName    = "GoKBBI"
 	AppVersion = "1.0.0"
@@ -58,8 +86,43 @@ func main() {
 		return
 	}
 
+	// Handle subperintah (misalnya "kbbi status")
+	if *kata == "" && flag.NArg() > 0 {
+		switch flag.Arg(0) {
+		case "status":
+			parseArgumenPerintah(1)
+			if err := terapkanProfil(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if err := tampilkanStatus(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "akun":
+			if err := perintahAkun(flag.Arg(1)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "profil":
+			if err := perintahProfil(flag.Arg(1), flag.Arg(2)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	// Arahkan kuki, kredensial, dan cache ke profil jika --profil diberikan
+	if err := terapkanProfil(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Handle perintah autentikasi
-	if *autentikasi && (*email != "" || *sandi != "") {
+	if *autentikasi {
 		if err := lakukanAutentikasi(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error autentikasi: %v\n", err)
 			os.Exit(1)
@@ -97,16 +160,29 @@ func main() {
 // tampilkanBantuan menampilkan panduan penggunaan
 func tampilkanBantuan() {
 	fmt.Printf("%s v%s - %s\n\n", AppName, AppVersion, AppDesc)
-	
+
 	fmt.Println("PENGGUNAAN:")
 	fmt.Printf("  %s [OPTIONS] <kata>\n", os.Args[0])
-	fmt.Printf("  %s --kata <kata> [OPTIONS]\n\n", os.Args[0])
-	
+	fmt.Printf("  %s --kata <kata> [OPTIONS]\n", os.Args[0])
+	fmt.Printf("  %s <perintah> [OPTIONS]\n\n", os.Args[0])
+
+	fmt.Println("PERINTAH:")
+	fmt.Println("  status                    Tampilkan status layanan KBBI Daring dan sesi")
+	fmt.Println("  akun status               Tampilkan sesi tersimpan, email akun, umur kuki,")
+	fmt.Println("                            dan jumlah permintaan hari ini")
+	fmt.Println("  akun siapa                Tampilkan email akun pada sesi tersimpan")
+	fmt.Println("  akun keluar               Keluar dari KBBI lalu hapus kuki tersimpan")
+	fmt.Println("  profil daftar             Tampilkan semua profil akun")
+	fmt.Println("  profil buat <nama>        Buat profil dengan --email, --file-kredensial,")
+	fmt.Println("                            --pembantu-kredensial, dan --cache-sendiri")
+	fmt.Println("  profil hapus <nama>       Hapus profil beserta kuki, penghitung, dan cache-nya")
+	fmt.Println()
+
 	fmt.Println("CONTOH:")
 	fmt.Printf("  %s cinta\n", os.Args[0])
 	fmt.Printf("  %s --kata rumah --json\n", os.Args[0])
-	fmt.Printf("  %s --email user@email.com --sandi password --autentikasi\n\n", os.Args[0])
-	
+	fmt.Printf("  %s --email user@email.com --autentikasi\n\n", os.Args[0])
+
 	fmt.Println("OPTIONS:")
 	fmt.Println("  Pencarian:")
 	fmt.Println("    --kata <kata>           Kata yang ingin dicari")
@@ -114,40 +190,205 @@ func tampilkanBantuan() {
 	fmt.Println("    --indent                Gunakan indentasi untuk JSON (hanya dengan --json)")
 	fmt.Println("    --tanpa-contoh          Jangan tampilkan contoh penggunaan")
 	fmt.Println("    --tanpa-terkait         Jangan tampilkan kata terkait")
+	fmt.Println("    --tanpa-lampiran        Jangan tampilkan lampiran entri")
 	fmt.Println("    --tanpa-cache           Langsung request ke KBBI tanpa menggunakan cache")
 	fmt.Println("    --nonpengguna           Nonaktifkan fitur khusus pengguna")
-	
+
+	fmt.Println("\n  Debug:")
+	fmt.Println("    --debug-html <dir>      Simpan HTML mentah, URL, header, dan hasil parsing")
+	fmt.Println("                            ketika tidak ada entri atau halaman kesalahan terdeteksi")
+	fmt.Println("    --debug-selalu          Simpan setiap halaman (hanya dengan --debug-html)")
+	fmt.Println("    --ketat                 Gagal jika struktur halaman KBBI tidak dikenali parser")
+	fmt.Println("    --diagnostik            Tampilkan peringatan parser (entri, selektor, cuplikan)")
+
 	fmt.Println("\n  Autentikasi:")
 	fmt.Println("    --email <email>         Alamat email akun KBBI")
-	fmt.Println("    --sandi <password>      Kata sandi akun KBBI")
+	fmt.Println("    --sandi <password>      Kata sandi akun KBBI (usang, gunakan sumber lain)")
 	fmt.Println("    --autentikasi           Lakukan proses autentikasi")
 	fmt.Println("    --lokasi-kuki <path>    Lokasi file kuki (default: ~/.kbbi/kuki.json)")
-	fmt.Println("    --bersihkan-kuki        Hapus kuki yang tersimpan")
-	
+	fmt.Println("    --bersihkan-kuki        Hapus kuki lokal saja (sesi di KBBI tetap aktif,")
+	fmt.Println("                            gunakan \"akun keluar\" untuk keluar sepenuhnya)")
+	fmt.Println("    --enkripsi-kuki         Enkripsi file kuki dengan frasa sandi (argon2id + AES-GCM)")
+	fmt.Println("    --file-frasa-sandi <path> File berisi frasa sandi kuki terenkripsi")
+	fmt.Println("    --file-kredensial <path>  File JSON {\"email\", \"sandi\"} (default: ~/.kbbi/kredensial.json)")
+	fmt.Println("    --pembantu-kredensial <cmd> Perintah yang menulis email=... dan sandi=... ke stdout")
+
+	fmt.Println("\n  Profil:")
+	fmt.Println("    --profil <nama>         Gunakan kuki, kredensial, penghitung permintaan, dan")
+	fmt.Println("                            cache dari profil ~/.kbbi/profil/<nama>")
+	fmt.Println("    --cache-sendiri         Profil memakai cache terpisah (dengan \"profil buat\")")
+
 	fmt.Println("\n  Lainnya:")
 	fmt.Println("    --bantuan, --help       Tampilkan bantuan ini")
 	fmt.Println("    --version               Tampilkan versi aplikasi")
-	
+
 	fmt.Println("\nCATATAN:")
 	fmt.Println("  - Fitur pengguna terdaftar memerlukan autentikasi dengan akun KBBI")
 	fmt.Println("  - Setelah autentikasi berhasil, kuki akan disimpan otomatis")
+	fmt.Println("  - Kredensial dicari berurutan: --email/--sandi, KBBI_EMAIL/KBBI_SANDI,")
+	fmt.Println("    --pembantu-kredensial atau KBBI_PEMBANTU_KREDENSIAL, --file-kredensial atau")
+	fmt.Println("    KBBI_FILE_KREDENSIAL atau ~/.kbbi/kredensial.json (wajib chmod 600), ~/.netrc")
+	fmt.Println("    (machine kbbi.kemdikbud.go.id), lalu ditanyakan di terminal tanpa ditampilkan")
+	fmt.Println("  - Frasa sandi kuki terenkripsi diambil dari KBBI_FRASA_SANDI, --file-frasa-sandi")
+	fmt.Println("    atau KBBI_FILE_FRASA_SANDI, lalu ditanyakan di terminal")
 	fmt.Println("  - Gunakan pencarian secara wajar untuk menghindari pemblokiran akun")
+	fmt.Println("  - Untuk mencari kata yang sama dengan nama perintah, gunakan --kata")
+}
+
+// parseArgumenPerintah mengurai ulang flag yang muncul setelah nama perintah
+func parseArgumenPerintah(n int) {
+	if flag.NArg() > n {
+		flag.CommandLine.Parse(flag.Args()[n:])
+	}
+}
+
+// tampilkanStatus menampilkan status layanan KBBI Daring
+func tampilkanStatus() error {
+	var autentikasiObj *auth.AutentikasiKBBI
+	if !*nonpengguna {
+		// Gunakan kuki yang tersimpan jika ada, abaikan jika tidak ada
+		autentikasiObj, _ = muatAuthStatus()
+	}
+
+	status, errStatus := fetcher.CekStatus(autentikasiObj)
+
+	if *outputJSON {
+		var data []byte
+		var err error
+		if *indentJSON {
+			data, err = json.MarshalIndent(status, "", "  ")
+		} else {
+			data, err = json.Marshal(status)
+		}
+		if err != nil {
+			return fmt.Errorf("gagal mengkonversi ke JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Println(status.String())
+		if autentikasiObj == nil {
+			fmt.Println("(tidak ada kuki tersimpan, sesi tidak diperiksa)")
+		}
+	}
+
+	return errStatus
+}
+
+// perintahAkun menangani subperintah "kbbi akun"
+func perintahAkun(sub string) error {
+	parseArgumenPerintah(2)
+	if err := terapkanProfil(); err != nil {
+		return err
+	}
+
+	switch sub {
+	case "status":
+		return tampilkanStatusAkun()
+	case "siapa":
+		return tampilkanEmailAkun()
+	case "keluar":
+		return keluarAkun()
+	case "":
+		return fmt.Errorf("subperintah akun diperlukan: status, siapa, atau keluar")
+	default:
+		return fmt.Errorf("subperintah akun tidak dikenal: %s", sub)
+	}
+}
+
+// muatAuthAkun memuat kuki tersimpan apa adanya, tanpa validasi dan login ulang
+func muatAuthAkun() (*auth.AutentikasiKBBI, error) {
+	autentikasiObj, err := auth.BaruAuthDenganOpsi(auth.OpsiAuth{
+		LokasiKuki:    *lokasiKuki,
+		FrasaSandi:    sumberFrasaSandi(),
+		TanpaValidasi: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tidak ada sesi tersimpan: %w", err)
+	}
+	return autentikasiObj, nil
+}
+
+// tampilkanStatusAkun menampilkan keadaan sesi akun yang tersimpan
+func tampilkanStatusAkun() error {
+	autentikasiObj, err := muatAuthAkun()
+	if err != nil {
+		return err
+	}
+
+	info, errStatus := autentikasiObj.StatusSesi()
+
+	if *outputJSON {
+		var data []byte
+		if *indentJSON {
+			data, err = json.MarshalIndent(info, "", "  ")
+		} else {
+			data, err = json.Marshal(info)
+		}
+		if err != nil {
+			return fmt.Errorf("gagal mengkonversi ke JSON: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Println(info.String())
+	}
+
+	return errStatus
+}
+
+// tampilkanEmailAkun menampilkan email akun pada sesi tersimpan
+func tampilkanEmailAkun() error {
+	autentikasiObj, err := muatAuthAkun()
+	if err != nil {
+		return err
+	}
+	if autentikasiObj.EmailAkun() == "" {
+		return fmt.Errorf("email akun tidak tercatat pada kuki, lakukan --autentikasi ulang")
+	}
+
+	fmt.Println(autentikasiObj.EmailAkun())
+	return nil
+}
+
+// keluarAkun mengakhiri sesi di KBBI lalu menghapus kuki tersimpan
+func keluarAkun() error {
+	autentikasiObj, err := muatAuthAkun()
+	if err != nil {
+		return err
+	}
+
+	if err := autentikasiObj.Keluar(); err != nil {
+		return fmt.Errorf("gagal keluar: %w (gunakan --bersihkan-kuki untuk menghapus kuki lokal saja)", err)
+	}
+
+	fmt.Println("Berhasil keluar dari KBBI.")
+	fmt.Printf("Kuki telah dihapus dari: %s\n", autentikasiObj.LokasiKuki)
+	return nil
 }
 
 // lakukanAutentikasi menangani proses autentikasi
 func lakukanAutentikasi() error {
-	if *email == "" {
-		fmt.Print("Masukkan email: ")
-		fmt.Scanln(email)
+	if *sandi != "" {
+		fmt.Fprintln(os.Stderr, "Peringatan: --sandi usang karena sandi terlihat di daftar proses dan riwayat shell.")
+		fmt.Fprintln(os.Stderr, "Gunakan KBBI_SANDI, --file-kredensial, --pembantu-kredensial, atau prompt terminal.")
+	}
+
+	// Ambil kredensial sesuai urutan prioritas, prompt terminal paling akhir
+	k, err := kredensial.Rantai(sumberKredensial(), kredensial.DariPrompt())(*email)
+	if errors.Is(err, kredensial.ErrTidakAda) {
+		return fmt.Errorf("email dan sandi tidak ditemukan; gunakan --email dengan prompt terminal, KBBI_EMAIL/KBBI_SANDI, atau --file-kredensial")
 	}
-	
-	if *sandi == "" {
-		fmt.Print("Masukkan kata sandi: ")
-		fmt.Scanln(sandi)
+	if err != nil {
+		return err
 	}
 
 	// Buat objek autentikasi
-	autentikasiObj, err := auth.BaruAuth(*email, *sandi, *lokasiKuki)
+	autentikasiObj, err := auth.BaruAuthDenganOpsi(auth.OpsiAuth{
+		Email:      k.Email,
+		Sandi:      k.Sandi,
+		LokasiKuki: *lokasiKuki,
+		Enkripsi:   *enkripsiKuki,
+		FrasaSandi: sumberFrasaSandiBaru(),
+	})
 	if err != nil {
 		return fmt.Errorf("gagal melakukan autentikasi: %w", err)
 	}
@@ -157,10 +398,18 @@ func lakukanAutentikasi() error {
 		return fmt.Errorf("gagal menyimpan kuki: %w", err)
 	}
 
+	// Catat email akun pada profil yang belum memilikinya
+	if profilAktif != nil && profilAktif.Email == "" {
+		profilAktif.Email = autentikasiObj.EmailAkun()
+		if err := profilAktif.Simpan(); err != nil {
+			fmt.Fprintf(os.Stderr, "Peringatan: %v\n", err)
+		}
+	}
+
 	fmt.Println("Autentikasi berhasil!")
 	fmt.Printf("Kuki telah disimpan di: %s\n", autentikasiObj.LokasiKuki)
 	fmt.Println("Kuki akan otomatis digunakan pada pencarian berikutnya.")
-	
+
 	return nil
 }
 
@@ -195,50 +444,73 @@ func lakukanPencarian() error {
 	// Cek apakah ada kuki yang tersimpan
 	if !*nonpengguna {
 		// Coba buat autentikasi dengan kuki yang ada
-		autentikasiObj, err = auth.BaruAuth("", "", *lokasiKuki)
+		autentikasiObj, err = muatAuthTersimpan()
 		if err != nil {
 			// Jika gagal, lanjutkan tanpa autentikasi
+			if errors.Is(err, auth.ErrSesiKedaluwarsa) {
+				peringatkanSesiBerakhir(err)
+			}
 			autentikasiObj = nil
+		} else {
+			autentikasiObj.SaatSesiBerakhir = peringatkanSesiBerakhir
+			// Tulis penghitung permintaan yang masih tertunda sebelum
+			// keluar, termasuk ketika pencarian gagal
+			defer autentikasiObj.SimpanPenghitung()
 		}
 	}
 
 	// Ambil definisi dari KBBI Kemendikbud
-	var html string
-	if *tanpaCache {
-		html, err = fetcher.AmbilHalaman(*kata, autentikasiObj)
-	} else {
-		html, err = fetcher.AmbilHalamanDenganCache(*kata, autentikasiObj, *lokasiKuki, *tanpaCache)
+	var definisi *model.Definisi
+	respons, errAmbil := fetcher.AmbilResponsDenganCache(*kata, autentikasiObj, lokasiCache, *tanpaCache)
+
+	// Simpan halaman mentah untuk debug setelah hasil parsing diketahui
+	errDebug := errAmbil
+	if *debugHTML != "" {
+		defer func() {
+			simpanDebug(respons, definisi, errDebug)
+		}()
 	}
-	
-	if err != nil {
+
+	opsiParser := parser.Opsi{Tampilan: parser.TampilanUmum, Ketat: *ketat, TanpaLampiran: *tanpaLampiran}
+	if autentikasiObj != nil && autentikasiObj.Terautentikasi() {
+		opsiParser.Tampilan = parser.TampilanPengguna
+	}
+	if errAmbil != nil {
 		// Jika error adalah TidakDitemukan dan ada HTML, parse untuk saran
-		if kesalahanKBBI, ok := err.(*fetcher.KesalahanKBBI); ok && kesalahanKBBI.Jenis == "TidakDitemukan" && html != "" {
-			// Parse saran entri
-			terautentikasi := autentikasiObj != nil && autentikasiObj.Terautentikasi
-			definisi, parseErr := parser.ParseDefinisi(html, terautentikasi)
-			if parseErr != nil {
-				return parseErr
+		if errors.Is(errAmbil, fetcher.ErrTidakDitemukan) && respons != nil && respons.HTML != "" {
+			// Parse saran entri; halaman tanpa saran adalah hasil yang sah
+			opsiSaran := opsiParser
+			opsiSaran.Ketat = false
+			definisi, _, err = parser.ParseDefinisiDari(strings.NewReader(respons.HTML), opsiSaran)
+			if err != nil {
+				return err
 			}
 			parser.SetPranala(definisi, *kata)
-			
+
 			// Tampilkan hasil dengan saran
 			if len(definisi.SaranEntri) > 0 {
 				return tampilkanHasil(definisi)
 			}
 		}
-		return fmt.Errorf("gagal mengambil data dari KBBI: %w", err)
+		return fmt.Errorf("gagal mengambil data dari KBBI: %w", errAmbil)
 	}
-	
+
 	// Parse HTML menjadi definisi
-	terautentikasi := autentikasiObj != nil && autentikasiObj.Terautentikasi
-	definisi, err := parser.ParseDefinisi(html, terautentikasi)
+	var daftarDiagnostik []parser.Diagnostik
+	definisi, daftarDiagnostik, err = parser.ParseDefinisiDari(strings.NewReader(respons.HTML), opsiParser)
+	if *diagnostik {
+		for _, d := range daftarDiagnostik {
+			fmt.Fprintf(os.Stderr, "Diagnostik parser: %s\n", d)
+		}
+	}
 	if err != nil {
+		errDebug = err
 		return fmt.Errorf("gagal parsing definisi: %w", err)
 	}
-	
+
 	// Set pranala
 	parser.SetPranala(definisi, *kata)
-	
+
 	// Jika tidak ada entri ditemukan, tampilkan pesan
 	if len(definisi.Entri) == 0 && len(definisi.SaranEntri) == 0 {
 		if !*outputJSON {
@@ -251,6 +523,234 @@ func lakukanPencarian() error {
 	return tampilkanHasil(definisi)
 }
 
+// sumberFrasaSandi menentukan urutan sumber frasa sandi kuki terenkripsi:
+// variabel lingkungan, file, lalu prompt terminal
+func sumberFrasaSandi() auth.SumberFrasaSandi {
+	return auth.RantaiFrasaSandi(
+		auth.FrasaSandiDariEnv(),
+		auth.FrasaSandiDariFile(*fileFrasaSandi),
+		auth.FrasaSandiDariPrompt(),
+	)
+}
+
+// sumberFrasaSandiBaru seperti sumberFrasaSandi, tetapi frasa sandi dari
+// prompt diminta dua kali karena dipakai untuk mengenkripsi kuki baru
+func sumberFrasaSandiBaru() auth.SumberFrasaSandi {
+	return auth.RantaiFrasaSandi(
+		auth.FrasaSandiDariEnv(),
+		auth.FrasaSandiDariFile(*fileFrasaSandi),
+		auth.FrasaSandiBaruDariPrompt(),
+	)
+}
+
+// muatAuthStatus memuat kuki tersimpan untuk pemeriksaan status tanpa
+// login ulang maupun prompt; kuki terenkripsi hanya dibuka jika frasa
+// sandinya tersedia dari lingkungan atau file
+func muatAuthStatus() (*auth.AutentikasiKBBI, error) {
+	return auth.BaruAuthDenganOpsi(auth.OpsiAuth{
+		LokasiKuki: *lokasiKuki,
+		FrasaSandi: auth.RantaiFrasaSandi(
+			auth.FrasaSandiDariEnv(),
+			auth.FrasaSandiDariFile(*fileFrasaSandi),
+		),
+		TanpaValidasi: true,
+	})
+}
+
+// muatAuthTersimpan memuat autentikasi dari kuki yang tersimpan
+func muatAuthTersimpan() (*auth.AutentikasiKBBI, error) {
+	return auth.BaruAuthDenganOpsi(auth.OpsiAuth{
+		LokasiKuki: *lokasiKuki,
+		FrasaSandi: sumberFrasaSandi(),
+		Kredensial: sumberKredensial(),
+	})
+}
+
+// sumberKredensial menentukan urutan sumber kredensial non-interaktif:
+// flag, rujukan kredensial profil, variabel lingkungan, pembantu kredensial,
+// file kredensial, lalu .netrc
+func sumberKredensial() kredensial.Sumber {
+	sumber := []kredensial.Sumber{kredensial.Tetap(*email, *sandi, "flag")}
+	if profilAktif != nil {
+		sumber = append(sumber, profilAktif.SumberKredensial()...)
+	}
+	sumber = append(sumber,
+		kredensial.DariEnv(),
+		kredensial.DariPembantu(*pembantuKredensial),
+		kredensial.DariFile(*fileKredensial),
+		kredensial.DariNetrc(""),
+	)
+	return kredensial.Rantai(sumber...)
+}
+
+// terapkanProfil mengarahkan lokasi kuki dan cache ke profil --profil
+func terapkanProfil() error {
+	lokasiCache = *lokasiKuki
+	if *namaProfil == "" {
+		return nil
+	}
+	if *lokasiKuki != "" {
+		return fmt.Errorf("--profil dan --lokasi-kuki tidak dapat dipakai bersamaan")
+	}
+
+	p, err := profil.Muat(*namaProfil)
+	if err != nil {
+		return fmt.Errorf("%w (buat dengan \"profil buat %s\")", err, *namaProfil)
+	}
+
+	profilAktif = p
+	*lokasiKuki = p.LokasiKuki()
+	lokasiCache = p.AcuanCache()
+	return nil
+}
+
+// perintahProfil menangani subperintah "kbbi profil"
+func perintahProfil(sub, nama string) error {
+	switch sub {
+	case "daftar":
+		parseArgumenPerintah(2)
+		return tampilkanDaftarProfil()
+	case "buat":
+		parseArgumenPerintah(3)
+		return buatProfil(nama)
+	case "hapus":
+		parseArgumenPerintah(3)
+		return hapusProfil(nama)
+	case "":
+		return fmt.Errorf("subperintah profil diperlukan: daftar, buat, atau hapus")
+	default:
+		return fmt.Errorf("subperintah profil tidak dikenal: %s", sub)
+	}
+}
+
+// tampilkanDaftarProfil menampilkan semua profil akun
+func tampilkanDaftarProfil() error {
+	daftar, err := profil.Daftar()
+	if err != nil {
+		return err
+	}
+
+	if *outputJSON {
+		if daftar == nil {
+			daftar = []*profil.Profil{}
+		}
+		var data []byte
+		if *indentJSON {
+			data, err = json.MarshalIndent(daftar, "", "  ")
+		} else {
+			data, err = json.Marshal(daftar)
+		}
+		if err != nil {
+			return fmt.Errorf("gagal mengkonversi ke JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(daftar) == 0 {
+		fmt.Println("Belum ada profil. Buat dengan \"profil buat <nama>\".")
+		return nil
+	}
+
+	for _, p := range daftar {
+		email := p.Email
+		if email == "" {
+			email = "-"
+		}
+		kuki := "belum masuk"
+		if p.AdaKuki() {
+			kuki = "kuki tersimpan"
+		}
+		cacheProfil := "cache bersama"
+		if p.CacheSendiri {
+			cacheProfil = "cache sendiri"
+		}
+		fmt.Printf("%-16s %-32s %-15s %s\n", p.Nama, email, kuki, cacheProfil)
+	}
+
+	return nil
+}
+
+// buatProfil membuat profil baru dari flag --email, --file-kredensial,
+// --pembantu-kredensial, dan --cache-sendiri
+func buatProfil(nama string) error {
+	if nama == "" {
+		return fmt.Errorf("nama profil diperlukan: profil buat <nama>")
+	}
+	if *sandi != "" {
+		return fmt.Errorf("profil tidak menyimpan sandi; gunakan --file-kredensial atau --pembantu-kredensial")
+	}
+
+	fileKred := *fileKredensial
+	if fileKred != "" {
+		abs, err := filepath.Abs(fileKred)
+		if err != nil {
+			return fmt.Errorf("gagal menentukan lokasi file kredensial: %w", err)
+		}
+		fileKred = abs
+	}
+
+	p, err := profil.Buat(profil.Profil{
+		Nama:               nama,
+		Email:              *email,
+		FileKredensial:     fileKred,
+		PembantuKredensial: *pembantuKredensial,
+		CacheSendiri:       *cacheSendiri,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Profil %s dibuat di: %s\n", p.Nama, p.Direktori)
+	fmt.Printf("Masuk dengan: %s --profil %s --autentikasi\n", os.Args[0], p.Nama)
+	return nil
+}
+
+// hapusProfil menghapus profil beserta kuki dan cache-nya
+func hapusProfil(nama string) error {
+	if nama == "" {
+		return fmt.Errorf("nama profil diperlukan: profil hapus <nama>")
+	}
+
+	p, err := profil.Muat(nama)
+	if err != nil {
+		return err
+	}
+	if p.AdaKuki() {
+		fmt.Fprintf(os.Stderr, "Catatan: kuki dihapus tanpa mengakhiri sesi di KBBI (gunakan \"akun keluar --profil %s\" untuk keluar terlebih dahulu).\n", nama)
+	}
+
+	if err := profil.Hapus(nama); err != nil {
+		return err
+	}
+
+	fmt.Printf("Profil %s dihapus.\n", nama)
+	return nil
+}
+
+// peringatkanSesiBerakhir memberi tahu pengguna bahwa hasil turun menjadi
+// hasil pengguna umum karena sesi sudah berakhir
+func peringatkanSesiBerakhir(err error) {
+	fmt.Fprintf(os.Stderr, "Peringatan: %v\n", err)
+	fmt.Fprintln(os.Stderr, "Pencarian dilanjutkan tanpa fitur pengguna terdaftar. Lakukan --autentikasi ulang.")
+}
+
+// simpanDebug menyimpan HTML mentah dan metadata ke direktori --debug-html
+func simpanDebug(respons *fetcher.Respons, definisi *model.Definisi, err error) {
+	mode := debug.ModeSaatGagal
+	if *debugSelalu {
+		mode = debug.ModeSelalu
+	}
+
+	lokasi, errSimpan := debug.BaruPenyimpanDebug(*debugHTML, mode).Simpan(respons, definisi, err)
+	if errSimpan != nil {
+		fmt.Fprintf(os.Stderr, "Peringatan: %v\n", errSimpan)
+		return
+	}
+	if lokasi != "" {
+		fmt.Fprintf(os.Stderr, "HTML debug disimpan: %s\n", lokasi)
+	}
+}
 
 // tampilkanHasil menampilkan hasil pencarian
 func tampilkanHasil(definisi *model.Definisi) error {
@@ -261,22 +761,30 @@ func tampilkanHasil(definisi *model.Definisi) error {
 		}
 		fmt.Println(jsonStr)
 	} else {
+		// Bagian kata terkait lain tidak memiliki judul tetap sehingga
+		// dihapus dari data, bukan dari teks
+		if *tanpaTerkait {
+			for i := range definisi.Entri {
+				definisi.Entri[i].Terkait = nil
+			}
+		}
+
 		// Filter output berdasarkan flag
 		output := definisi.String()
-		
+
 		// Filter contoh jika diminta
 		if *tanpaContoh {
 			output = hapusContoh(output)
 		}
-		
-		// Filter terkait jika diminta  
+
+		// Filter terkait jika diminta
 		if *tanpaTerkait {
 			output = hapusTerkait(output)
 		}
-		
+
 		fmt.Println(output)
 	}
-	
+
 	return nil
 }
 
@@ -284,13 +792,15 @@ func tampilkanHasil(definisi *model.Definisi) error {
 func hapusContoh(text string) string {
 	lines := strings.Split(text, "\n")
 	var hasil []string
-	
+
 	for _, line := range lines {
 		// Hapus bagian setelah ": " yang merupakan contoh
 		if idx := strings.Index(line, ": "); idx != -1 {
-			// Kecuali jika baris dimulai dengan angka (penomoran makna)
+			// Kecuali jika baris dimulai dengan angka atau huruf (penomoran
+			// makna dan makna anak)
 			trimmed := strings.TrimSpace(line)
-			if len(trimmed) > 0 && (trimmed[0] >= '1' && trimmed[0] <= '9') {
+			if len(trimmed) > 0 && (trimmed[0] >= '1' && trimmed[0] <= '9') ||
+				len(trimmed) > 2 && trimmed[0] >= 'a' && trimmed[0] <= 'z' && trimmed[1] == '.' {
 				// Ini adalah makna bernomor, hapus bagian contoh
 				hasil = append(hasil, line[:idx])
 			} else {
@@ -300,7 +810,7 @@ func hapusContoh(text string) string {
 			hasil = append(hasil, line)
 		}
 	}
-	
+
 	return strings.Join(hasil, "\n")
 }
 
@@ -309,42 +819,42 @@ func hapusTerkait(text string) string {
 	lines := strings.Split(text, "\n")
 	var hasil []string
 	skipMode := false
-	
+
 	for _, line := range lines {
 		trimmed := strings.TrimSpace(line)
-		
+
 		// Skip baris yang dimulai dengan angka dan → (rujukan internal dalam makna)
 		if skipMode || strings.HasPrefix(trimmed, "Kata Turunan") ||
-		   strings.HasPrefix(trimmed, "Gabungan Kata") {
+			strings.HasPrefix(trimmed, "Gabungan Kata") {
 			skipMode = true
 			continue
 		}
-		
+
 		// Skip baris yang berisi rujukan internal
 		if strings.Contains(trimmed, "→") {
 			continue
 		}
-		
+
 		// Reset skip mode jika menemukan baris kosong dan bukan dalam daftar terkait
 		if skipMode && trimmed == "" {
 			skipMode = false
 			continue
 		}
-		
+
 		// Skip jika dalam mode skip dan berisi ; (indikator daftar)
 		if skipMode && strings.Contains(trimmed, ";") {
 			continue
 		}
-		
+
 		// Reset skip mode jika bukan bagian dari daftar terkait
 		if skipMode && !strings.Contains(trimmed, ";") && trimmed != "" {
 			skipMode = false
 		}
-		
+
 		if !skipMode {
 			hasil = append(hasil, line)
 		}
 	}
-	
+
 	return strings.Join(hasil, "\n")
-}
\ No newline at end of file
+}
@@ -2,9 +2,14 @@ module github.com/ZulfaNurhuda/GoKBBI.project
 
 go 1.21
 
-require github.com/PuerkitoBio/goquery v1.8.1
+require (
+	github.com/PuerkitoBio/goquery v1.8.1
+	golang.org/x/crypto v0.15.0
+	golang.org/x/term v0.14.0
+)
 
 require (
 	github.com/andybalholm/cascadia v1.3.1 // indirect
 	golang.org/x/net v0.17.0 // indirect
+	golang.org/x/sys v0.14.0 // indirect
 )
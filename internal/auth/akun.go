@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
+)
+
+// InfoSesi berisi keterangan sesi akun yang tersimpan
+type InfoSesi struct {
+	Email             string     `json:"email,omitempty"`
+	LokasiKuki        string     `json:"lokasi_kuki"`
+	Valid             bool       `json:"valid"`
+	Diperiksa         bool       `json:"diperiksa"`
+	Disimpan          *time.Time `json:"disimpan,omitempty"`
+	Kedaluwarsa       *time.Time `json:"kedaluwarsa,omitempty"`
+	PermintaanHariIni int        `json:"permintaan_hari_ini"`
+	Kesalahan         string     `json:"kesalahan,omitempty"`
+}
+
+// StatusSesi memeriksa sesi tersimpan ke KBBI tanpa login ulang
+//
+// Info yang dikembalikan selalu terisi. Jika KBBI tidak dapat dijangkau,
+// Diperiksa bernilai false dan error dikembalikan.
+func (a *AutentikasiKBBI) StatusSesi() (*InfoSesi, error) {
+	info := &InfoSesi{
+		Email:             a.EmailAkun(),
+		LokasiKuki:        a.LokasiKuki,
+		PermintaanHariIni: a.PermintaanHariIni(),
+	}
+
+	a.mu.RLock()
+	disimpan := a.disimpan
+	a.mu.RUnlock()
+	if !disimpan.IsZero() {
+		info.Disimpan = &disimpan
+	}
+
+	// Cari waktu kedaluwarsa kuki sesi utama
+	u, _ := url.Parse(situs.Host())
+	for _, kuki := range a.jar.kukiUntukHost(u.Hostname(), time.Now()) {
+		if kuki.Nama == NamaKukiUtama {
+			info.Kedaluwarsa = kuki.Kedaluwarsa
+		}
+	}
+
+	// Tanpa kuki utama, sesi pasti tidak valid
+	if !a.Terautentikasi() {
+		info.Diperiksa = true
+		return info, nil
+	}
+
+	valid, err := a.ValidasiSesi()
+	if err != nil {
+		info.Kesalahan = err.Error()
+		return info, err
+	}
+
+	info.Diperiksa = true
+	info.Valid = valid
+	return info, nil
+}
+
+// Keluar mengirim permintaan keluar ke KBBI agar sesi di server berakhir,
+// lalu menghapus file kuki
+//
+// Jika permintaan keluar gagal, file kuki tidak dihapus dan error
+// dikembalikan, sehingga sesi di server tidak tertinggal tanpa diketahui.
+func (a *AutentikasiKBBI) Keluar() error {
+	// Cegah login ulang dari goroutine lain selama proses keluar
+	a.muLogin.Lock()
+	defer a.muLogin.Unlock()
+
+	body, masuk, err := a.periksaBeranda()
+	if err != nil {
+		return err
+	}
+
+	// Kirim permintaan keluar hanya jika sesi di server masih aktif;
+	// jika sudah berakhir, cukup hapus kuki lokal
+	if masuk {
+		token, err := cariToken(body)
+		if err != nil {
+			return fmt.Errorf("gagal mengambil token keluar: %w", err)
+		}
+
+		data := url.Values{}
+		data.Set("__RequestVerificationToken", token)
+
+		respKeluar, err := a.client.PostForm(situs.URL(LokasiKeluar), data)
+		if err != nil {
+			return fmt.Errorf("gagal mengirim permintaan keluar: %w", err)
+		}
+		defer respKeluar.Body.Close()
+
+		bodyKeluar, err := io.ReadAll(respKeluar.Body)
+		if err != nil {
+			return fmt.Errorf("gagal membaca hasil permintaan keluar: %w", err)
+		}
+
+		if strings.Contains(respKeluar.Request.URL.String(), "Beranda/Error") || a.CekAutentikasi(string(bodyKeluar)) {
+			return fmt.Errorf("KBBI tidak mengakhiri sesi")
+		}
+	}
+
+	a.aturTerautentikasi(false)
+
+	a.muKuki.Lock()
+	defer a.muKuki.Unlock()
+	if err := os.Remove(a.LokasiKuki); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("gagal menghapus kuki: %w", err)
+	}
+
+	return nil
+}
+
+// String mengembalikan representasi string dari InfoSesi
+func (i *InfoSesi) String() string {
+	var hasil []string
+
+	email := i.Email
+	if email == "" {
+		email = "(tidak diketahui)"
+	}
+	hasil = append(hasil, fmt.Sprintf("Akun: %s", email))
+	hasil = append(hasil, fmt.Sprintf("Kuki: %s", i.LokasiKuki))
+
+	switch {
+	case !i.Diperiksa:
+		hasil = append(hasil, fmt.Sprintf("Sesi: tidak dapat diperiksa (%s)", i.Kesalahan))
+	case i.Valid:
+		hasil = append(hasil, "Sesi: masih berlaku")
+	default:
+		hasil = append(hasil, "Sesi: sudah berakhir")
+	}
+
+	sekarang := time.Now()
+	if i.Disimpan != nil {
+		hasil = append(hasil, fmt.Sprintf("Disimpan: %s (%s lalu)",
+			i.Disimpan.Local().Format(time.DateTime), durasi(sekarang.Sub(*i.Disimpan))))
+	}
+	if i.Kedaluwarsa != nil {
+		hasil = append(hasil, fmt.Sprintf("Kedaluwarsa: %s (%s lagi)",
+			i.Kedaluwarsa.Local().Format(time.DateTime), durasi(i.Kedaluwarsa.Sub(sekarang))))
+	} else if i.Valid {
+		hasil = append(hasil, "Kedaluwarsa: saat sesi peramban berakhir")
+	}
+
+	hasil = append(hasil, fmt.Sprintf("Permintaan hari ini: %d", i.PermintaanHariIni))
+
+	return strings.Join(hasil, "\n")
+}
+
+// durasi menyatakan lama waktu dalam hari, jam, atau menit
+func durasi(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%d hari", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%d jam", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%d menit", int(d.Minutes()))
+	}
+}
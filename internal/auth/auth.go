@@ -3,36 +3,152 @@ package auth
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"net/http/cookiejar"
 	"net/url"
 	"os"
 	"path/filepath"
-	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/kredensial"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/profil"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
 )
 
 const (
-	HostKBBI      = "https://kbbi.kemdikbud.go.id"
+	HostKBBI      = situs.HostBawaan
 	LokasiLogin   = "Account/Login"
+	LokasiKeluar  = "Account/LogOff"
 	NamaKukiUtama = ".AspNet.ApplicationCookie"
 )
 
+// ErrSesiKedaluwarsa dikembalikan ketika sesi yang tersimpan sudah tidak
+// berlaku dan tidak dapat dipulihkan dengan login ulang. Hasil pencarian
+// setelahnya adalah hasil untuk pengguna umum (tanpa etimologi, dll).
+var ErrSesiKedaluwarsa = errors.New("sesi autentikasi KBBI sudah berakhir")
+
 // AutentikasiKBBI mengelola autentikasi dengan KBBI Daring
+//
+// Satu objek aman dipakai bersamaan dari banyak goroutine. Status sesi
+// hanya dibaca melalui Terautentikasi dan EmailAkun; Email dan Sandi hanya
+// boleh diubah sebelum objek dipakai bersamaan.
 type AutentikasiKBBI struct {
-	Email       string
-	Sandi       string
-	LokasiKuki  string
-	client      *http.Client
-	Terautentikasi bool
+	Email      string
+	Sandi      string
+	LokasiKuki string
+	client     *http.Client
+	jar        *jarKuki
+
+	// SaatSesiBerakhir dipanggil ketika sesi berakhir dan tidak dapat
+	// dipulihkan, sehingga hasil berikutnya turun menjadi hasil pengguna umum.
+	// Fungsi ini dipanggil tanpa memegang kunci login, sehingga boleh
+	// memanggil Login atau PulihkanSesi.
+	SaatSesiBerakhir func(err error)
+
+	// Enkripsi menentukan apakah file kuki dienkripsi saat disimpan
+	Enkripsi bool
+
+	// FrasaSandi adalah sumber frasa sandi untuk file kuki terenkripsi,
+	// nil berarti FrasaSandiBawaan
+	FrasaSandi SumberFrasaSandi
+
+	// Kredensial adalah sumber email dan sandi untuk login ulang ketika
+	// Email dan Sandi kosong, misalnya saat sesi dimuat dari kuki
+	Kredensial kredensial.Sumber
+
+	// Profil adalah profil akun yang dipakai, nil jika tidak memakai profil
+	Profil *profil.Profil
+
+	// mu melindungi status sesi, generasi login, Email, Sandi, dan disimpan
+	mu             sync.RWMutex
+	terautentikasi bool
+	generasi       uint64
+	errLogin       error
+	disimpan       time.Time
+
+	// muLogin memastikan hanya ada satu login yang berjalan
+	muLogin sync.Mutex
+
+	// muKuki menyerialkan baca tulis file kuki dan melindungi Enkripsi
+	// serta frasa sandi yang sudah diambil
+	muKuki     sync.Mutex
+	frasaSandi string
+
+	// muPermintaan melindungi file penghitung permintaan harian beserta
+	// permintaan yang belum disimpan
+	muPermintaan   sync.Mutex
+	tertunda       catatanPermintaan
+	terakhirSimpan time.Time
+	timerSimpan    *time.Timer
+}
+
+// OpsiAuth adalah pengaturan untuk membuat objek AutentikasiKBBI
+type OpsiAuth struct {
+	Email      string
+	Sandi      string
+	LokasiKuki string
+
+	// Enkripsi menentukan apakah file kuki dienkripsi saat disimpan
+	Enkripsi bool
+
+	// FrasaSandi adalah sumber frasa sandi untuk file kuki terenkripsi,
+	// nil berarti FrasaSandiBawaan
+	FrasaSandi SumberFrasaSandi
+
+	// Kredensial adalah sumber email dan sandi ketika Email dan Sandi kosong.
+	// Dipakai untuk login jika kuki belum ada dan untuk login ulang ketika
+	// sesi berakhir.
+	Kredensial kredensial.Sumber
+
+	// TanpaValidasi memuat kuki apa adanya tanpa memeriksa sesi ke KBBI
+	// dan tanpa login ulang, misalnya untuk menampilkan status akun
+	TanpaValidasi bool
+
+	// Profil adalah nama profil akun (~/.kbbi/profil/<nama>). Jika diisi,
+	// kuki dan penghitung permintaan diambil dari direktori profil, dan
+	// Kredensial kosong diganti dengan rujukan kredensial profil. Tidak
+	// boleh diisi bersamaan dengan LokasiKuki.
+	Profil string
 }
 
 // BaruAuth membuat objek AutentikasiKBBI baru
 func BaruAuth(email, sandi, lokasiKuki string) (*AutentikasiKBBI, error) {
+	return BaruAuthDenganOpsi(OpsiAuth{
+		Email:      email,
+		Sandi:      sandi,
+		LokasiKuki: lokasiKuki,
+	})
+}
+
+// BaruAuthDenganOpsi membuat objek AutentikasiKBBI baru dengan pengaturan tambahan
+func BaruAuthDenganOpsi(opsi OpsiAuth) (*AutentikasiKBBI, error) {
+	email, sandi, lokasiKuki := opsi.Email, opsi.Sandi, opsi.LokasiKuki
+
+	// Ambil lokasi kuki dan kredensial dari profil
+	var profilAkun *profil.Profil
+	if opsi.Profil != "" {
+		if lokasiKuki != "" {
+			return nil, fmt.Errorf("profil dan lokasi kuki tidak dapat dipakai bersamaan")
+		}
+
+		var err error
+		profilAkun, err = profil.Muat(opsi.Profil)
+		if err != nil {
+			return nil, err
+		}
+		lokasiKuki = profilAkun.LokasiKuki()
+		if opsi.Kredensial == nil {
+			opsi.Kredensial = profilAkun.Kredensial()
+		}
+	}
+
 	// Buat cookie jar untuk mengelola session
-	jar, err := cookiejar.New(nil)
+	jar, err := baruJarKuki()
 	if err != nil {
 		return nil, fmt.Errorf("gagal membuat cookie jar: %w", err)
 	}
@@ -55,11 +171,31 @@ func BaruAuth(email, sandi, lokasiKuki string) (*AutentikasiKBBI, error) {
 		Sandi:      sandi,
 		LokasiKuki: lokasiKuki,
 		client:     client,
+		jar:        jar,
+		Enkripsi:   opsi.Enkripsi,
+		FrasaSandi: opsi.FrasaSandi,
+		Kredensial: opsi.Kredensial,
+		Profil:     profilAkun,
 	}
 
 	// Jika email dan sandi kosong, coba muat kuki
 	if email == "" && sandi == "" {
-		err = auth.MuatKuki()
+		if _, errStat := os.Stat(lokasiKuki); os.IsNotExist(errStat) && auth.Kredensial != nil {
+			// Belum ada kuki, login dengan kredensial dari sumber yang diatur
+			if err = auth.Login(); err != nil {
+				return nil, err
+			}
+			if err = auth.SimpanKuki(); err != nil {
+				return nil, fmt.Errorf("gagal menyimpan kuki: %w", err)
+			}
+			return auth, nil
+		}
+
+		if opsi.TanpaValidasi {
+			err = auth.muatFileKuki()
+		} else {
+			err = auth.MuatKuki()
+		}
 		if err != nil {
 			return nil, fmt.Errorf("tidak dapat memuat kuki: %w", err)
 		}
@@ -75,138 +211,399 @@ func BaruAuth(email, sandi, lokasiKuki string) (*AutentikasiKBBI, error) {
 }
 
 // Login melakukan autentikasi ke KBBI Daring
+//
+// Jika Email atau Sandi kosong, kredensial diambil dari sumber Kredensial.
+// Kegagalan dikembalikan sebagai *KesalahanLogin yang cocok dengan salah
+// satu sentinel (ErrKredensialSalah, ErrCaptcha, dst.) melalui errors.Is.
+// Jika login sedang berjalan di goroutine lain, Login menunggu hingga
+// login tersebut selesai.
 func (a *AutentikasiKBBI) Login() error {
-	// Ambil token CSRF
-	token, err := a.ambilToken()
+	a.muLogin.Lock()
+	defer a.muLogin.Unlock()
+
+	err := a.loginDenganKredensial()
+	a.catatLogin(err)
+	return err
+}
+
+// login mengirim form login ke KBBI; pemanggil harus memegang muLogin
+func (a *AutentikasiKBBI) login() error {
+	a.mu.RLock()
+	email, sandi := a.Email, a.Sandi
+	a.mu.RUnlock()
+
+	// Ambil form login beserta token CSRF dan field tersembunyi lainnya
+	form, err := a.ambilFormLogin()
 	if err != nil {
-		return fmt.Errorf("gagal mengambil token: %w", err)
+		return err
 	}
 
-	// Siapkan data form untuk login
-	data := url.Values{}
-	data.Set("__RequestVerificationToken", token)
-	data.Set("Posel", a.Email)
-	data.Set("KataSandi", a.Sandi)
+	// Lengkapi data form untuk login
+	data := form.Field
+	data.Set("Posel", email)
+	data.Set("KataSandi", sandi)
 	data.Set("IngatSaya", "true")
 
 	// Kirim permintaan login
-	resp, err := a.client.PostForm(fmt.Sprintf("%s/%s", HostKBBI, LokasiLogin), data)
+	resp, err := a.client.PostForm(form.Aksi, data)
 	if err != nil {
 		return fmt.Errorf("gagal melakukan login: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Periksa hasil login
-	if strings.Contains(resp.Request.URL.String(), "Beranda/Error") {
-		return fmt.Errorf("terjadi kesalahan saat memproses permintaan login")
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("gagal membaca hasil login: %w", err)
 	}
-	
-	if strings.Contains(resp.Request.URL.String(), "Account/Login") {
-		return fmt.Errorf("gagal melakukan autentikasi dengan alamat posel dan sandi yang diberikan")
+
+	// Periksa hasil login
+	if err := klasifikasiLogin(body, resp.Request.URL.String(), resp.StatusCode); err != nil {
+		return err
 	}
 
-	a.Terautentikasi = true
+	a.aturTerautentikasi(true)
 	return nil
 }
 
-// SimpanKuki menyimpan kuki autentikasi ke file
+// SimpanKuki menyimpan seluruh kuki KBBI beserta atributnya ke file
 func (a *AutentikasiKBBI) SimpanKuki() error {
+	a.muKuki.Lock()
+	defer a.muKuki.Unlock()
+
 	// Buat direktori jika belum ada
 	dir := filepath.Dir(a.LokasiKuki)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("gagal membuat direktori: %w", err)
 	}
 
-	// Ambil kuki dari client
-	u, _ := url.Parse(HostKBBI)
-	cookies := a.client.Jar.Cookies(u)
-	
-	kukiData := make(map[string]string)
-	for _, cookie := range cookies {
-		if cookie.Name == NamaKukiUtama {
-			kukiData[cookie.Name] = cookie.Value
-			break
-		}
+	// Ambil kuki yang masih berlaku dari jar
+	u, _ := url.Parse(situs.Host())
+	sekarang := time.Now()
+	kukiData := FileKuki{
+		Versi:    VersiFormatKuki,
+		Disimpan: sekarang,
+		Email:    a.EmailAkun(),
+		Kuki:     a.jar.kukiUntukHost(u.Hostname(), sekarang),
 	}
 
+	// Urutkan agar file stabil antar penyimpanan
+	sort.Slice(kukiData.Kuki, func(i, j int) bool {
+		return kukiData.Kuki[i].Nama < kukiData.Kuki[j].Nama
+	})
+
 	// Simpan ke file JSON
-	data, err := json.Marshal(kukiData)
+	data, err := json.MarshalIndent(kukiData, "", "  ")
 	if err != nil {
 		return fmt.Errorf("gagal mengenkode kuki: %w", err)
 	}
 
+	// Enkripsi isi file jika diminta
+	if a.Enkripsi {
+		frasa, err := a.ambilFrasaSandi()
+		if err != nil {
+			return fmt.Errorf("gagal mengenkripsi kuki: %w", err)
+		}
+		data, err = enkripsiKuki(data, frasa)
+		if err != nil {
+			return fmt.Errorf("gagal mengenkripsi kuki: %w", err)
+		}
+	}
+
 	if err := os.WriteFile(a.LokasiKuki, data, 0600); err != nil {
 		return fmt.Errorf("gagal menyimpan kuki: %w", err)
 	}
 
+	a.mu.Lock()
+	a.disimpan = sekarang
+	a.mu.Unlock()
+
 	return nil
 }
 
 // MuatKuki memuat kuki autentikasi dari file
+//
+// Kuki yang sudah kedaluwarsa diabaikan; jika kuki sesi utama kedaluwarsa,
+// sesi dianggap sudah keluar. File dengan format lama dimigrasi otomatis.
 func (a *AutentikasiKBBI) MuatKuki() error {
+	if err := a.muatFileKuki(); err != nil {
+		return err
+	}
+
+	// Pastikan kuki masih berlaku sebelum dianggap terautentikasi
+	if !a.Terautentikasi() {
+		return a.PulihkanSesi()
+	}
+
+	valid, err := a.ValidasiSesi()
+	if err != nil {
+		// KBBI tidak dapat dijangkau, anggap kuki masih berlaku;
+		// kedaluwarsa akan terdeteksi pada permintaan berikutnya
+		return nil
+	}
+	if !valid {
+		return a.PulihkanSesi()
+	}
+
+	return nil
+}
+
+// muatFileKuki membaca file kuki ke jar tanpa memeriksa sesi ke KBBI
+func (a *AutentikasiKBBI) muatFileKuki() error {
+	perluMigrasi, err := a.bacaKuki()
+	if err != nil {
+		return err
+	}
+
+	// Tulis ulang file dengan format terbaru, abaikan error penyimpanan
+	if perluMigrasi {
+		a.SimpanKuki()
+	}
+
+	return nil
+}
+
+// bacaKuki membaca dan mendekripsi file kuki lalu memasukkannya ke jar
+//
+// Mengembalikan true jika file masih memakai format lama.
+func (a *AutentikasiKBBI) bacaKuki() (bool, error) {
+	a.muKuki.Lock()
+	defer a.muKuki.Unlock()
+
 	data, err := os.ReadFile(a.LokasiKuki)
 	if err != nil {
-		return fmt.Errorf("kuki tidak ditemukan pada %s", a.LokasiKuki)
+		return false, fmt.Errorf("kuki tidak ditemukan pada %s", a.LokasiKuki)
+	}
+
+	// Dekripsi file kuki jika terenkripsi
+	amplop, err := bacaAmplopKuki(data)
+	if err != nil {
+		return false, fmt.Errorf("gagal membaca kuki: %w", err)
+	}
+	if amplop != nil {
+		frasa, err := a.ambilFrasaSandi()
+		if err != nil {
+			return false, fmt.Errorf("kuki pada %s terenkripsi: %w", a.LokasiKuki, err)
+		}
+		data, err = dekripsiKuki(amplop, frasa)
+		if err != nil {
+			return false, fmt.Errorf("gagal mendekripsi kuki: %w", err)
+		}
+		// Pertahankan enkripsi saat kuki disimpan ulang
+		a.Enkripsi = true
 	}
 
-	var kukiData map[string]string
-	if err := json.Unmarshal(data, &kukiData); err != nil {
-		return fmt.Errorf("gagal membaca kuki: %w", err)
+	u, _ := url.Parse(situs.Host())
+	kukiData, perluMigrasi, err := bacaFileKuki(data, u.Hostname())
+	if err != nil {
+		return false, fmt.Errorf("gagal membaca kuki: %w", err)
 	}
 
-	// Set kuki ke client
-	u, _ := url.Parse(HostKBBI)
-	for name, value := range kukiData {
-		if name == NamaKukiUtama {
-			cookie := &http.Cookie{
-				Name:  name,
-				Value: value,
-			}
-			a.client.Jar.SetCookies(u, []*http.Cookie{cookie})
-			a.Terautentikasi = true
-			break
+	// Set kuki yang masih berlaku ke client
+	sekarang := time.Now()
+	adaKukiUtama := false
+	for _, kuki := range kukiData.Kuki {
+		if kuki.SudahKedaluwarsa(sekarang) {
+			continue
 		}
+		a.jar.pulihkan(kuki, u.Scheme)
+		if kuki.Nama == NamaKukiUtama {
+			adaKukiUtama = true
+		}
+	}
+
+	a.mu.Lock()
+	if adaKukiUtama {
+		a.terautentikasi = true
+	}
+	a.disimpan = kukiData.Disimpan
+	if a.Email == "" {
+		a.Email = kukiData.Email
+	}
+	a.mu.Unlock()
+
+	return perluMigrasi, nil
+}
+
+// ValidasiSesi memeriksa ke KBBI apakah sesi saat ini masih masuk
+//
+// Jika status sesi tidak dapat ditentukan, misalnya KBBI menampilkan halaman
+// kesalahan, halaman pemeliharaan, atau halaman akun dibekukan, error
+// dikembalikan dan status sesi tidak diubah.
+func (a *AutentikasiKBBI) ValidasiSesi() (bool, error) {
+	_, masuk, err := a.periksaBeranda()
+	if err != nil {
+		return false, fmt.Errorf("gagal memvalidasi sesi: %w", err)
+	}
+
+	a.aturTerautentikasi(masuk)
+	return masuk, nil
+}
+
+// periksaBeranda mengambil beranda KBBI dan menentukan apakah sesi masih
+// masuk berdasarkan status respons, URL akhir, dan penanda sesi pada halaman
+func (a *AutentikasiKBBI) periksaBeranda() ([]byte, bool, error) {
+	resp, err := a.client.Get(situs.Host())
+	if err != nil {
+		return nil, false, fmt.Errorf("gagal mengakses KBBI: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("gagal membaca halaman KBBI: %w", err)
+	}
+
+	urlAkhir := resp.Request.URL.String()
+	switch {
+	case strings.Contains(urlAkhir, "Beranda/Error") || resp.StatusCode != http.StatusOK:
+		return nil, false, ErrKesalahanSitus.denganKonteks(nil, urlAkhir, resp.StatusCode)
+	case strings.Contains(urlAkhir, "Account/Banned"):
+		return nil, false, ErrAkunTerkunci.denganKonteks(nil, urlAkhir, resp.StatusCode)
+	}
+
+	masuk, pasti := statusHalaman(string(body))
+	if !pasti {
+		return nil, false, fmt.Errorf("status sesi tidak dapat ditentukan dari halaman %s", urlAkhir)
+	}
+
+	return body, masuk, nil
+}
+
+// PulihkanSesi melakukan login ulang ketika sesi berakhir
+//
+// Login ulang hanya dilakukan jika email dan sandi tersedia, langsung atau
+// melalui sumber Kredensial; kuki hasil login ulang langsung disimpan. Jika
+// sesi tidak dapat dipulihkan, SaatSesiBerakhir dipanggil dan error yang
+// membungkus ErrSesiKedaluwarsa dikembalikan.
+func (a *AutentikasiKBBI) PulihkanSesi() error {
+	return a.PulihkanSesiSejak(a.Generasi())
+}
+
+// PulihkanSesiSejak melakukan login ulang untuk sesi yang diamati berakhir
+// pada generasi tertentu (lihat Generasi)
+//
+// Jika goroutine lain sudah mencoba login ulang sejak generasi tersebut,
+// login tidak diulang dan hasil percobaan itu yang dikembalikan, sehingga
+// pencarian paralel yang sama-sama mendapati sesi berakhir hanya memicu
+// satu login.
+func (a *AutentikasiKBBI) PulihkanSesiSejak(generasi uint64) error {
+	berakhir, err := a.pulihkanSesiSejak(generasi)
+	if berakhir && a.SaatSesiBerakhir != nil {
+		a.SaatSesiBerakhir(err)
+	}
+	return err
+}
+
+// pulihkanSesiSejak melakukan login ulang sambil memegang muLogin; berakhir
+// bernilai true jika percobaan ini mendapati sesi tidak dapat dipulihkan
+func (a *AutentikasiKBBI) pulihkanSesiSejak(generasi uint64) (berakhir bool, err error) {
+	a.muLogin.Lock()
+	defer a.muLogin.Unlock()
+
+	a.mu.RLock()
+	generasiSaatIni, errSebelumnya := a.generasi, a.errLogin
+	a.mu.RUnlock()
+	if generasiSaatIni != generasi {
+		return false, errSebelumnya
+	}
+
+	a.aturTerautentikasi(false)
+	err = a.pulihkanSesi()
+	a.catatLogin(err)
+	return errors.Is(err, ErrSesiKedaluwarsa), err
+}
+
+// pulihkanSesi melakukan login ulang; pemanggil harus memegang muLogin
+func (a *AutentikasiKBBI) pulihkanSesi() error {
+	a.mu.RLock()
+	lengkap := a.Email != "" && a.Sandi != ""
+	a.mu.RUnlock()
+
+	if !lengkap && a.Kredensial == nil {
+		return ErrSesiKedaluwarsa
+	}
+
+	if err := a.loginDenganKredensial(); err != nil {
+		if errors.Is(err, kredensial.ErrTidakAda) {
+			return ErrSesiKedaluwarsa
+		}
+		return fmt.Errorf("%w: login ulang gagal: %w", ErrSesiKedaluwarsa, err)
+	}
+
+	if err := a.SimpanKuki(); err != nil {
+		return fmt.Errorf("login ulang berhasil tetapi gagal menyimpan kuki: %w", err)
 	}
 
 	return nil
 }
 
+// loginDenganKredensial melakukan login dengan Email dan Sandi, atau dengan
+// kredensial dari sumber Kredensial jika keduanya belum lengkap; pemanggil
+// harus memegang muLogin
+func (a *AutentikasiKBBI) loginDenganKredensial() error {
+	a.mu.RLock()
+	email, lengkap := a.Email, a.Email != "" && a.Sandi != ""
+	a.mu.RUnlock()
+
+	if !lengkap && a.Kredensial != nil {
+		k, err := a.Kredensial(email)
+		if err != nil {
+			return err
+		}
+		a.mu.Lock()
+		a.Email, a.Sandi = k.Email, k.Sandi
+		a.mu.Unlock()
+	}
+
+	return a.login()
+}
+
 // GetClient mengembalikan http.Client yang sudah terautentikasi
 func (a *AutentikasiKBBI) GetClient() *http.Client {
 	return a.client
 }
 
-// CekAutentikasi memeriksa apakah sesi masih terautentikasi
+// CekAutentikasi memeriksa apakah sesi masih terautentikasi berdasarkan
+// halaman dari KBBI dan memperbarui status sesi
+//
+// Halaman tanpa tautan masuk maupun form keluar, misalnya halaman kesalahan,
+// tidak mengubah status sesi dan status saat ini yang dikembalikan.
 func (a *AutentikasiKBBI) CekAutentikasi(htmlContent string) bool {
-	a.Terautentikasi = !strings.Contains(htmlContent, "loginLink")
-	return a.Terautentikasi
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if masuk, pasti := statusHalaman(htmlContent); pasti {
+		a.terautentikasi = masuk
+	}
+	return a.terautentikasi
 }
 
-// ambilToken mengambil token CSRF dari halaman login
-func (a *AutentikasiKBBI) ambilToken() (string, error) {
-	resp, err := a.client.Get(fmt.Sprintf("%s/%s", HostKBBI, LokasiLogin))
+// ambilFormLogin mengambil dan mengurai form pada halaman login
+func (a *AutentikasiKBBI) ambilFormLogin() (*formLogin, error) {
+	resp, err := a.client.Get(situs.URL(LokasiLogin))
 	if err != nil {
-		return "", fmt.Errorf("gagal mengakses halaman login: %w", err)
+		return nil, fmt.Errorf("gagal mengakses halaman login: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("gagal membaca halaman login: %w", err)
+		return nil, fmt.Errorf("gagal membaca halaman login: %w", err)
 	}
 
-	// Cari token CSRF menggunakan regex
-	re := regexp.MustCompile(`<input name="__RequestVerificationToken".*value="([^"]*)"`)
-	matches := re.FindSubmatch(body)
-	if len(matches) < 2 {
-		return "", fmt.Errorf("token CSRF tidak ditemukan")
+	urlAkhir := resp.Request.URL.String()
+	if strings.Contains(urlAkhir, "Beranda/Error") || resp.StatusCode >= 500 {
+		return nil, ErrKesalahanSitus.denganKonteks(nil, urlAkhir, resp.StatusCode)
 	}
 
-	return string(matches[1]), nil
+	return parseFormLogin(body, urlAkhir)
 }
 
 // HapusKuki menghapus file kuki yang tersimpan
 func (a *AutentikasiKBBI) HapusKuki() error {
+	a.muKuki.Lock()
+	defer a.muKuki.Unlock()
+
 	if err := os.Remove(a.LokasiKuki); err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("kuki tidak ditemukan pada %s", a.LokasiKuki)
@@ -0,0 +1,315 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/term"
+)
+
+const (
+	// EnvFrasaSandi adalah variabel lingkungan berisi frasa sandi file kuki
+	EnvFrasaSandi = "KBBI_FRASA_SANDI"
+
+	// EnvFileFrasaSandi adalah variabel lingkungan berisi lokasi file frasa sandi
+	EnvFileFrasaSandi = "KBBI_FILE_FRASA_SANDI"
+
+	// Parameter argon2id untuk menurunkan kunci dari frasa sandi
+	argonWaktu   = 3
+	argonMemori  = 64 * 1024
+	argonParalel = 4
+	panjangKunci = 32
+	panjangSalt  = 16
+
+	// Batas parameter argon2id yang diterima dari file kuki, agar file yang
+	// rusak atau diubah tidak membuat proses panik atau menghabiskan memori
+	batasWaktu  = 16
+	batasMemori = 1024 * 1024
+)
+
+// ErrFrasaSandiTidakAda dikembalikan ketika sumber frasa sandi tidak
+// menyediakan frasa sandi
+var ErrFrasaSandiTidakAda = errors.New("frasa sandi untuk kuki terenkripsi tidak tersedia")
+
+// ErrFrasaSandiSalah dikembalikan ketika file kuki gagal didekripsi
+var ErrFrasaSandiSalah = errors.New("frasa sandi salah atau file kuki rusak")
+
+// ErrKukiRusak dikembalikan ketika parameter enkripsi pada file kuki tidak
+// valid, misalnya karena file rusak atau diubah
+var ErrKukiRusak = errors.New("format file kuki terenkripsi tidak valid")
+
+// ErrFrasaSandiTidakCocok dikembalikan ketika frasa sandi baru dan
+// konfirmasinya berbeda
+var ErrFrasaSandiTidakCocok = errors.New("frasa sandi dan konfirmasinya tidak cocok")
+
+// SumberFrasaSandi menyediakan frasa sandi untuk enkripsi file kuki
+type SumberFrasaSandi func() (string, error)
+
+// amplopKuki merepresentasikan file kuki terenkripsi
+type amplopKuki struct {
+	Versi    int               `json:"versi"`
+	Enkripsi parameterEnkripsi `json:"enkripsi"`
+	Data     []byte            `json:"data"`
+}
+
+// parameterEnkripsi menyimpan parameter KDF dan cipher yang dipakai
+type parameterEnkripsi struct {
+	KDF     string `json:"kdf"`
+	Cipher  string `json:"cipher"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Waktu   uint32 `json:"waktu"`
+	Memori  uint32 `json:"memori"`
+	Paralel uint8  `json:"paralel"`
+}
+
+// FrasaSandiDariEnv mengambil frasa sandi dari variabel lingkungan KBBI_FRASA_SANDI
+func FrasaSandiDariEnv() SumberFrasaSandi {
+	return func() (string, error) {
+		frasa := os.Getenv(EnvFrasaSandi)
+		if frasa == "" {
+			return "", ErrFrasaSandiTidakAda
+		}
+		return frasa, nil
+	}
+}
+
+// FrasaSandiDariFile mengambil frasa sandi dari baris pertama sebuah file
+//
+// Jika lokasi kosong, lokasi diambil dari variabel lingkungan KBBI_FILE_FRASA_SANDI.
+func FrasaSandiDariFile(lokasi string) SumberFrasaSandi {
+	return func() (string, error) {
+		lokasi := lokasi
+		if lokasi == "" {
+			lokasi = os.Getenv(EnvFileFrasaSandi)
+		}
+		if lokasi == "" {
+			return "", ErrFrasaSandiTidakAda
+		}
+
+		data, err := os.ReadFile(lokasi)
+		if err != nil {
+			return "", fmt.Errorf("gagal membaca file frasa sandi: %w", err)
+		}
+
+		frasa := strings.TrimRight(strings.SplitN(string(data), "\n", 2)[0], "\r")
+		if frasa == "" {
+			return "", ErrFrasaSandiTidakAda
+		}
+		return frasa, nil
+	}
+}
+
+// FrasaSandiDariPrompt meminta frasa sandi dari terminal tanpa menampilkannya
+func FrasaSandiDariPrompt() SumberFrasaSandi {
+	return func() (string, error) {
+		return bacaFrasaSandiTerminal("Masukkan frasa sandi kuki: ")
+	}
+}
+
+// FrasaSandiBaruDariPrompt meminta frasa sandi baru dari terminal dua kali
+// dan menolak jika keduanya berbeda, agar salah ketik tidak membuat kuki
+// terenkripsi tidak dapat dibuka
+func FrasaSandiBaruDariPrompt() SumberFrasaSandi {
+	return func() (string, error) {
+		frasa, err := bacaFrasaSandiTerminal("Masukkan frasa sandi baru untuk kuki: ")
+		if err != nil {
+			return "", err
+		}
+
+		konfirmasi, err := bacaFrasaSandiTerminal("Ulangi frasa sandi: ")
+		if err != nil {
+			return "", err
+		}
+		if frasa != konfirmasi {
+			return "", ErrFrasaSandiTidakCocok
+		}
+		return frasa, nil
+	}
+}
+
+// bacaFrasaSandiTerminal menampilkan label lalu membaca frasa sandi dari
+// terminal tanpa menampilkannya
+func bacaFrasaSandiTerminal(label string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", ErrFrasaSandiTidakAda
+	}
+
+	fmt.Fprint(os.Stderr, label)
+	data, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("gagal membaca frasa sandi: %w", err)
+	}
+	if len(data) == 0 {
+		return "", ErrFrasaSandiTidakAda
+	}
+	return string(data), nil
+}
+
+// RantaiFrasaSandi mencoba setiap sumber secara berurutan dan mengembalikan
+// frasa sandi pertama yang tersedia
+func RantaiFrasaSandi(sumber ...SumberFrasaSandi) SumberFrasaSandi {
+	return func() (string, error) {
+		for _, s := range sumber {
+			frasa, err := s()
+			if errors.Is(err, ErrFrasaSandiTidakAda) {
+				continue
+			}
+			return frasa, err
+		}
+		return "", ErrFrasaSandiTidakAda
+	}
+}
+
+// FrasaSandiBawaan mengambil frasa sandi dari KBBI_FRASA_SANDI, lalu dari
+// file pada KBBI_FILE_FRASA_SANDI
+func FrasaSandiBawaan() SumberFrasaSandi {
+	return RantaiFrasaSandi(FrasaSandiDariEnv(), FrasaSandiDariFile(""))
+}
+
+// ambilFrasaSandi mengambil frasa sandi dari sumber yang diatur, sekali per objek
+func (a *AutentikasiKBBI) ambilFrasaSandi() (string, error) {
+	if a.frasaSandi != "" {
+		return a.frasaSandi, nil
+	}
+
+	sumber := a.FrasaSandi
+	if sumber == nil {
+		sumber = FrasaSandiBawaan()
+	}
+
+	frasa, err := sumber()
+	if err != nil {
+		return "", err
+	}
+
+	a.frasaSandi = frasa
+	return frasa, nil
+}
+
+// enkripsiKuki mengenkripsi isi file kuki dengan kunci turunan argon2id
+// dan AES-256-GCM
+func enkripsiKuki(data []byte, frasa string) ([]byte, error) {
+	param := parameterEnkripsi{
+		KDF:     "argon2id",
+		Cipher:  "AES-256-GCM",
+		Salt:    make([]byte, panjangSalt),
+		Waktu:   argonWaktu,
+		Memori:  argonMemori,
+		Paralel: argonParalel,
+	}
+	if _, err := rand.Read(param.Salt); err != nil {
+		return nil, fmt.Errorf("gagal membuat salt: %w", err)
+	}
+
+	aead, err := buatAEAD(frasa, param)
+	if err != nil {
+		return nil, err
+	}
+
+	param.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(param.Nonce); err != nil {
+		return nil, fmt.Errorf("gagal membuat nonce: %w", err)
+	}
+
+	amplop := amplopKuki{
+		Versi:    VersiFormatKuki,
+		Enkripsi: param,
+		Data:     aead.Seal(nil, param.Nonce, data, dataTambahan(VersiFormatKuki)),
+	}
+
+	return json.MarshalIndent(amplop, "", "  ")
+}
+
+// dekripsiKuki membuka file kuki terenkripsi
+func dekripsiKuki(amplop *amplopKuki, frasa string) ([]byte, error) {
+	if amplop.Enkripsi.KDF != "argon2id" || amplop.Enkripsi.Cipher != "AES-256-GCM" {
+		return nil, fmt.Errorf("metode enkripsi kuki %s/%s tidak didukung",
+			amplop.Enkripsi.KDF, amplop.Enkripsi.Cipher)
+	}
+
+	if err := validasiParameter(amplop.Enkripsi); err != nil {
+		return nil, err
+	}
+
+	aead, err := buatAEAD(frasa, amplop.Enkripsi)
+	if err != nil {
+		return nil, err
+	}
+	if len(amplop.Enkripsi.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: panjang nonce %d, seharusnya %d",
+			ErrKukiRusak, len(amplop.Enkripsi.Nonce), aead.NonceSize())
+	}
+
+	data, err := aead.Open(nil, amplop.Enkripsi.Nonce, amplop.Data, dataTambahan(amplop.Versi))
+	if err != nil {
+		return nil, ErrFrasaSandiSalah
+	}
+
+	return data, nil
+}
+
+// validasiParameter memeriksa parameter KDF dari file kuki sebelum kunci
+// diturunkan
+func validasiParameter(param parameterEnkripsi) error {
+	switch {
+	case len(param.Salt) == 0:
+		return fmt.Errorf("%w: salt kosong", ErrKukiRusak)
+	case param.Paralel < 1:
+		return fmt.Errorf("%w: paralel %d", ErrKukiRusak, param.Paralel)
+	case param.Waktu < 1 || param.Waktu > batasWaktu:
+		return fmt.Errorf("%w: waktu %d di luar rentang 1..%d", ErrKukiRusak, param.Waktu, batasWaktu)
+	case param.Memori < 8*uint32(param.Paralel) || param.Memori > batasMemori:
+		return fmt.Errorf("%w: memori %d KiB di luar rentang %d..%d", ErrKukiRusak,
+			param.Memori, 8*uint32(param.Paralel), batasMemori)
+	}
+	return nil
+}
+
+// bacaAmplopKuki mengurai file kuki terenkripsi, atau nil jika file tidak terenkripsi
+func bacaAmplopKuki(data []byte) (*amplopKuki, error) {
+	var mentah map[string]json.RawMessage
+	if err := json.Unmarshal(data, &mentah); err != nil {
+		return nil, err
+	}
+	if _, ada := mentah["enkripsi"]; !ada {
+		return nil, nil
+	}
+
+	var amplop amplopKuki
+	if err := json.Unmarshal(data, &amplop); err != nil {
+		return nil, err
+	}
+	return &amplop, nil
+}
+
+// buatAEAD menurunkan kunci dari frasa sandi dan membuat cipher AES-GCM
+func buatAEAD(frasa string, param parameterEnkripsi) (cipher.AEAD, error) {
+	kunci := argon2.IDKey([]byte(frasa), param.Salt, param.Waktu, param.Memori, param.Paralel, panjangKunci)
+
+	block, err := aes.NewCipher(kunci)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuat GCM: %w", err)
+	}
+
+	return aead, nil
+}
+
+// dataTambahan mengikat ciphertext ke format file kuki GoKBBI
+func dataTambahan(versi int) []byte {
+	return []byte(fmt.Sprintf("gokbbi-kuki-v%d", versi))
+}
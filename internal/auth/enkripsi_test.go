@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDekripsiKukiParameterRusak(t *testing.T) {
+	data, err := enkripsiKuki([]byte(`{"versi":2}`), "frasa")
+	if err != nil {
+		t.Fatalf("enkripsiKuki: %v", err)
+	}
+	asli, err := bacaAmplopKuki(data)
+	if err != nil || asli == nil {
+		t.Fatalf("bacaAmplopKuki: %v", err)
+	}
+
+	kasus := map[string]func(p *parameterEnkripsi){
+		"nonce pendek":   func(p *parameterEnkripsi) { p.Nonce = p.Nonce[:3] },
+		"paralel nol":    func(p *parameterEnkripsi) { p.Paralel = 0 },
+		"waktu nol":      func(p *parameterEnkripsi) { p.Waktu = 0 },
+		"memori besar":   func(p *parameterEnkripsi) { p.Memori = 1 << 30 },
+		"memori kecil":   func(p *parameterEnkripsi) { p.Memori = 1 },
+		"salt kosong":    func(p *parameterEnkripsi) { p.Salt = nil },
+		"waktu berlebih": func(p *parameterEnkripsi) { p.Waktu = 1 << 20 },
+	}
+
+	for nama, ubah := range kasus {
+		t.Run(nama, func(t *testing.T) {
+			amplop := *asli
+			ubah(&amplop.Enkripsi)
+			if _, err := dekripsiKuki(&amplop, "frasa"); !errors.Is(err, ErrKukiRusak) {
+				t.Errorf("error = %v, ingin ErrKukiRusak", err)
+			}
+		})
+	}
+
+	if _, err := dekripsiKuki(asli, "salah"); !errors.Is(err, ErrFrasaSandiSalah) {
+		t.Errorf("frasa sandi salah: error = %v, ingin ErrFrasaSandiSalah", err)
+	}
+	if _, err := dekripsiKuki(asli, "frasa"); err != nil {
+		t.Errorf("frasa sandi benar: %v", err)
+	}
+}
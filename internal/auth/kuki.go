@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VersiFormatKuki adalah versi format file kuki yang ditulis oleh SimpanKuki
+//
+// Versi 1 adalah format lama berupa objek JSON {"nama": "nilai"} yang hanya
+// berisi kuki utama. Versi 2 menyimpan seluruh kuki KBBI beserta atributnya.
+const VersiFormatKuki = 2
+
+// Kuki merepresentasikan satu kuki beserta atributnya
+type Kuki struct {
+	Nama        string     `json:"nama"`
+	Nilai       string     `json:"nilai"`
+	Domain      string     `json:"domain"`
+	HostSaja    bool       `json:"host_saja"`
+	Path        string     `json:"path"`
+	Kedaluwarsa *time.Time `json:"kedaluwarsa,omitempty"`
+	Secure      bool       `json:"secure"`
+	HttpOnly    bool       `json:"http_only"`
+	SameSite    string     `json:"same_site,omitempty"`
+}
+
+// FileKuki merepresentasikan isi file kuki
+type FileKuki struct {
+	Versi    int       `json:"versi"`
+	Disimpan time.Time `json:"disimpan"`
+	Email    string    `json:"email,omitempty"`
+	Kuki     []Kuki    `json:"kuki"`
+}
+
+// SudahKedaluwarsa memeriksa apakah kuki sudah kedaluwarsa pada waktu tertentu
+//
+// Kuki sesi (tanpa waktu kedaluwarsa) dianggap masih berlaku.
+func (k *Kuki) SudahKedaluwarsa(waktu time.Time) bool {
+	return k.Kedaluwarsa != nil && !k.Kedaluwarsa.After(waktu)
+}
+
+// bacaFileKuki mengurai isi file kuki dari format apa pun yang didukung
+//
+// Mengembalikan true jika file masih memakai format lama dan perlu dimigrasi.
+func bacaFileKuki(data []byte, host string) (*FileKuki, bool, error) {
+	var mentah map[string]json.RawMessage
+	if err := json.Unmarshal(data, &mentah); err != nil {
+		return nil, false, err
+	}
+
+	// Format baru selalu memiliki field versi
+	if _, ada := mentah["versi"]; ada {
+		var file FileKuki
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, false, err
+		}
+		if file.Versi > VersiFormatKuki {
+			return nil, false, fmt.Errorf("versi format kuki %d tidak didukung", file.Versi)
+		}
+		return &file, false, nil
+	}
+
+	// Format lama: {".AspNet.ApplicationCookie": "nilai"}
+	var lama map[string]string
+	if err := json.Unmarshal(data, &lama); err != nil {
+		return nil, false, err
+	}
+
+	file := &FileKuki{Versi: 1}
+	for nama, nilai := range lama {
+		file.Kuki = append(file.Kuki, Kuki{
+			Nama:     nama,
+			Nilai:    nilai,
+			Domain:   host,
+			HostSaja: true,
+			Path:     "/",
+		})
+	}
+
+	return file, true, nil
+}
+
+// jarKuki adalah cookie jar yang juga merekam atribut lengkap setiap kuki,
+// karena cookiejar.Jar hanya mengembalikan nama dan nilai
+type jarKuki struct {
+	mu    sync.Mutex
+	jar   *cookiejar.Jar
+	rekam map[string]Kuki
+}
+
+// baruJarKuki membuat jarKuki baru
+func baruJarKuki() (*jarKuki, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jarKuki{
+		jar:   jar,
+		rekam: make(map[string]Kuki),
+	}, nil
+}
+
+// SetCookies menyimpan kuki ke jar dan merekam atributnya
+func (j *jarKuki) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	sekarang := time.Now()
+	for _, c := range cookies {
+		kuki := kukiDariHTTP(u, c, sekarang)
+		kunci := kuki.Domain + "|" + kuki.Path + "|" + kuki.Nama
+
+		if c.MaxAge < 0 || kuki.SudahKedaluwarsa(sekarang) {
+			delete(j.rekam, kunci)
+			continue
+		}
+		j.rekam[kunci] = kuki
+	}
+}
+
+// Cookies mengembalikan kuki yang akan dikirim ke URL
+func (j *jarKuki) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// kukiUntukHost mengembalikan semua kuki terekam yang berlaku untuk host
+func (j *jarKuki) kukiUntukHost(host string, waktu time.Time) []Kuki {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var hasil []Kuki
+	for _, kuki := range j.rekam {
+		if kuki.SudahKedaluwarsa(waktu) || !domainCocok(host, kuki.Domain, kuki.HostSaja) {
+			continue
+		}
+		hasil = append(hasil, kuki)
+	}
+
+	return hasil
+}
+
+// pulihkan memasukkan kembali kuki tersimpan ke jar beserta atributnya
+func (j *jarKuki) pulihkan(kuki Kuki, skema string) {
+	u := &url.URL{Scheme: skema, Host: strings.TrimPrefix(kuki.Domain, "."), Path: kuki.Path}
+
+	c := &http.Cookie{
+		Name:     kuki.Nama,
+		Value:    kuki.Nilai,
+		Path:     kuki.Path,
+		Secure:   kuki.Secure,
+		HttpOnly: kuki.HttpOnly,
+		SameSite: sameSiteDariString(kuki.SameSite),
+	}
+	if !kuki.HostSaja {
+		c.Domain = kuki.Domain
+	}
+	if kuki.Kedaluwarsa != nil {
+		c.Expires = *kuki.Kedaluwarsa
+	}
+
+	j.SetCookies(u, []*http.Cookie{c})
+}
+
+// kukiDariHTTP mengubah http.Cookie menjadi Kuki dengan atribut yang dilengkapi
+func kukiDariHTTP(u *url.URL, c *http.Cookie, sekarang time.Time) Kuki {
+	kuki := Kuki{
+		Nama:     c.Name,
+		Nilai:    c.Value,
+		Domain:   strings.ToLower(c.Domain),
+		Path:     c.Path,
+		Secure:   c.Secure,
+		HttpOnly: c.HttpOnly,
+		SameSite: sameSiteKeString(c.SameSite),
+	}
+
+	if kuki.Domain == "" {
+		kuki.Domain = strings.ToLower(u.Hostname())
+		kuki.HostSaja = true
+	}
+	if kuki.Path == "" || !strings.HasPrefix(kuki.Path, "/") {
+		kuki.Path = "/"
+	}
+
+	// Max-Age lebih diutamakan daripada Expires
+	if c.MaxAge > 0 {
+		kedaluwarsa := sekarang.Add(time.Duration(c.MaxAge) * time.Second)
+		kuki.Kedaluwarsa = &kedaluwarsa
+	} else if !c.Expires.IsZero() {
+		kedaluwarsa := c.Expires
+		kuki.Kedaluwarsa = &kedaluwarsa
+	}
+
+	return kuki
+}
+
+// domainCocok memeriksa apakah kuki dengan domain tertentu berlaku untuk host
+func domainCocok(host, domain string, hostSaja bool) bool {
+	domain = strings.TrimPrefix(domain, ".")
+	if hostSaja {
+		return host == domain
+	}
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// sameSiteKeString mengubah atribut SameSite menjadi string
+func sameSiteKeString(s http.SameSite) string {
+	switch s {
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	default:
+		return ""
+	}
+}
+
+// sameSiteDariString mengubah string menjadi atribut SameSite
+func sameSiteDariString(s string) http.SameSite {
+	switch s {
+	case "Lax":
+		return http.SameSiteLaxMode
+	case "Strict":
+		return http.SameSiteStrictMode
+	case "None":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteDefaultMode
+	}
+}
@@ -0,0 +1,301 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
+)
+
+// AlasanLogin merepresentasikan alasan kegagalan login
+type AlasanLogin int
+
+const (
+	AlasanTidakDikenal AlasanLogin = iota
+	AlasanKredensialSalah
+	AlasanBelumDikonfirmasi
+	AlasanAkunTerkunci
+	AlasanCaptcha
+	AlasanTokenTidakAda
+	AlasanKesalahanSitus
+)
+
+// String mengembalikan nama alasan kegagalan login
+func (a AlasanLogin) String() string {
+	switch a {
+	case AlasanKredensialSalah:
+		return "KredensialSalah"
+	case AlasanBelumDikonfirmasi:
+		return "BelumDikonfirmasi"
+	case AlasanAkunTerkunci:
+		return "AkunTerkunci"
+	case AlasanCaptcha:
+		return "Captcha"
+	case AlasanTokenTidakAda:
+		return "TokenTidakAda"
+	case AlasanKesalahanSitus:
+		return "KesalahanSitus"
+	default:
+		return "TidakDikenal"
+	}
+}
+
+// KesalahanLogin merepresentasikan kegagalan login ke KBBI
+//
+// Nilai sentinel (ErrKredensialSalah, dst.) hanya berisi Alasan dan Pesan.
+// Kesalahan yang dikembalikan Login membawa pesan validasi dari situs dan
+// URL akhir, dan tetap cocok dengan sentinelnya melalui errors.Is.
+type KesalahanLogin struct {
+	Alasan     AlasanLogin
+	Pesan      string
+	PesanSitus []string
+	URL        string
+	KodeStatus int
+	Err        error
+}
+
+func (e *KesalahanLogin) Error() string {
+	pesan := e.Pesan
+	if len(e.PesanSitus) > 0 {
+		pesan = fmt.Sprintf("%s (%s)", pesan, strings.Join(e.PesanSitus, "; "))
+	}
+	if e.Err != nil {
+		pesan = fmt.Sprintf("%s: %v", pesan, e.Err)
+	}
+	return pesan
+}
+
+// Unwrap mengembalikan error penyebab, jika ada
+func (e *KesalahanLogin) Unwrap() error {
+	return e.Err
+}
+
+// Is mencocokkan kesalahan berdasarkan alasannya sehingga
+// errors.Is(err, ErrCaptcha) tetap berlaku meskipun err membawa konteks
+func (e *KesalahanLogin) Is(target error) bool {
+	t, ok := target.(*KesalahanLogin)
+	if !ok {
+		return false
+	}
+	return t.Alasan == e.Alasan
+}
+
+// denganKonteks membuat salinan kesalahan yang dilengkapi konteks respons
+func (e *KesalahanLogin) denganKonteks(pesanSitus []string, urlAkhir string, kodeStatus int) *KesalahanLogin {
+	salinan := *e
+	salinan.PesanSitus = pesanSitus
+	salinan.URL = urlAkhir
+	salinan.KodeStatus = kodeStatus
+	return &salinan
+}
+
+// Kesalahan login yang bisa dikembalikan oleh Login
+var (
+	ErrKredensialSalah = &KesalahanLogin{
+		Alasan: AlasanKredensialSalah,
+		Pesan:  "Alamat posel atau kata sandi salah",
+	}
+	ErrAkunBelumDikonfirmasi = &KesalahanLogin{
+		Alasan: AlasanBelumDikonfirmasi,
+		Pesan:  "Akun belum dikonfirmasi, periksa posel konfirmasi dari KBBI",
+	}
+	ErrAkunTerkunci = &KesalahanLogin{
+		Alasan: AlasanAkunTerkunci,
+		Pesan:  "Akun terkunci atau dibekukan",
+	}
+	ErrCaptcha = &KesalahanLogin{
+		Alasan: AlasanCaptcha,
+		Pesan:  "KBBI meminta CAPTCHA, lakukan login melalui peramban",
+	}
+	ErrTokenTidakAda = &KesalahanLogin{
+		Alasan: AlasanTokenTidakAda,
+		Pesan:  "Token CSRF tidak ditemukan pada halaman login",
+	}
+	ErrKesalahanSitus = &KesalahanLogin{
+		Alasan: AlasanKesalahanSitus,
+		Pesan:  "Terjadi kesalahan pada situs KBBI saat memproses login",
+	}
+	ErrLoginTidakDikenal = &KesalahanLogin{
+		Alasan: AlasanTidakDikenal,
+		Pesan:  "Login gagal dengan alasan yang tidak dikenal",
+	}
+)
+
+// formLogin berisi aksi dan seluruh field form login
+type formLogin struct {
+	Aksi  string
+	Field url.Values
+}
+
+// parseFormLogin mengurai form login beserta seluruh field tersembunyinya
+func parseFormLogin(body []byte, urlHalaman string) (*formLogin, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("gagal parsing halaman login: %w", err)
+	}
+
+	// Pilih form yang memiliki field posel, atau form pertama yang mengarah
+	// ke halaman login
+	form := doc.Find("form").FilterFunction(func(i int, s *goquery.Selection) bool {
+		return s.Find(`input[name="Posel"]`).Length() > 0
+	}).First()
+	if form.Length() == 0 {
+		form = doc.Find(`form[action*="Account/Login"]`).First()
+	}
+
+	if adaCaptcha(doc.Selection) {
+		return nil, ErrCaptcha.denganKonteks(pesanValidasi(doc), urlHalaman, 0)
+	}
+
+	if form.Length() == 0 {
+		return nil, ErrTokenTidakAda.denganKonteks(nil, urlHalaman, 0)
+	}
+
+	hasil := &formLogin{
+		Aksi:  situs.URL(LokasiLogin),
+		Field: url.Values{},
+	}
+	if aksi, ada := form.Attr("action"); ada && aksi != "" {
+		if u, err := url.Parse(urlHalaman); err == nil {
+			if ref, err := u.Parse(aksi); err == nil {
+				hasil.Aksi = ref.String()
+			}
+		}
+	}
+
+	form.Find(`input[type="hidden"]`).Each(func(i int, s *goquery.Selection) {
+		nama, ada := s.Attr("name")
+		if !ada || nama == "" {
+			return
+		}
+		nilai, _ := s.Attr("value")
+		hasil.Field.Add(nama, nilai)
+	})
+
+	if hasil.Field.Get("__RequestVerificationToken") == "" {
+		return nil, ErrTokenTidakAda.denganKonteks(nil, urlHalaman, 0)
+	}
+
+	return hasil, nil
+}
+
+// klasifikasiLogin menentukan hasil login dari URL akhir dan isi respons;
+// nil berarti login berhasil
+func klasifikasiLogin(body []byte, urlAkhir string, kodeStatus int) error {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return ErrLoginTidakDikenal.denganKonteks(nil, urlAkhir, kodeStatus)
+	}
+	pesan := pesanValidasi(doc)
+
+	switch {
+	case strings.Contains(urlAkhir, "Beranda/Error") || kodeStatus >= 500:
+		return ErrKesalahanSitus.denganKonteks(pesan, urlAkhir, kodeStatus)
+	case strings.Contains(urlAkhir, "Account/Banned") || strings.Contains(urlAkhir, "Account/Lockout"):
+		return ErrAkunTerkunci.denganKonteks(pesan, urlAkhir, kodeStatus)
+	case strings.Contains(urlAkhir, "Account/Confirm") || strings.Contains(urlAkhir, "Account/Aktivasi"):
+		return ErrAkunBelumDikonfirmasi.denganKonteks(pesan, urlAkhir, kodeStatus)
+	}
+
+	// Masih berada di halaman login berarti login ditolak
+	if strings.Contains(urlAkhir, LokasiLogin) || doc.Find(`input[name="KataSandi"]`).Length() > 0 {
+		teks := strings.ToLower(strings.Join(pesan, " "))
+		switch {
+		case adaCaptcha(doc.Selection) || strings.Contains(teks, "captcha"):
+			return ErrCaptcha.denganKonteks(pesan, urlAkhir, kodeStatus)
+		case mengandungSalahSatu(teks, "konfirmasi", "aktivasi", "verifikasi", "belum aktif"):
+			return ErrAkunBelumDikonfirmasi.denganKonteks(pesan, urlAkhir, kodeStatus)
+		case mengandungSalahSatu(teks, "terkunci", "dikunci", "dibekukan", "diblokir", "lock"):
+			return ErrAkunTerkunci.denganKonteks(pesan, urlAkhir, kodeStatus)
+		default:
+			return ErrKredensialSalah.denganKonteks(pesan, urlAkhir, kodeStatus)
+		}
+	}
+
+	// Halaman tujuan masih menampilkan tautan masuk, sesi tidak terbentuk
+	if doc.Find("#loginLink").Length() > 0 {
+		return ErrLoginTidakDikenal.denganKonteks(pesan, urlAkhir, kodeStatus)
+	}
+
+	return nil
+}
+
+// pesanValidasi mengambil pesan validasi dan pesan kesalahan dari halaman
+func pesanValidasi(doc *goquery.Document) []string {
+	var pesan []string
+	terlihat := make(map[string]bool)
+
+	tambah := func(teks string) {
+		teks = strings.Join(strings.Fields(teks), " ")
+		if teks == "" || terlihat[teks] {
+			return
+		}
+		terlihat[teks] = true
+		pesan = append(pesan, teks)
+	}
+
+	doc.Find(".validation-summary-errors li").Each(func(i int, s *goquery.Selection) {
+		tambah(s.Text())
+	})
+	doc.Find(".field-validation-error, .alert-danger").Each(func(i int, s *goquery.Selection) {
+		tambah(s.Text())
+	})
+
+	return pesan
+}
+
+// adaCaptcha mendeteksi widget CAPTCHA pada halaman
+func adaCaptcha(s *goquery.Selection) bool {
+	if s.Find(".g-recaptcha, .h-captcha, [data-sitekey]").Length() > 0 {
+		return true
+	}
+
+	ada := false
+	s.Find("input[name], img[src], script[src]").EachWithBreak(func(i int, el *goquery.Selection) bool {
+		nilai, _ := el.Attr("name")
+		if nilai == "" {
+			nilai, _ = el.Attr("src")
+		}
+		if strings.Contains(strings.ToLower(nilai), "captcha") {
+			ada = true
+			return false
+		}
+		return true
+	})
+
+	return ada
+}
+
+// cariToken mencari token CSRF pada isi halaman, mengutamakan token pada
+// form keluar
+func cariToken(body []byte) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("gagal parsing halaman: %w", err)
+	}
+
+	input := doc.Find(`#logoutForm input[name="__RequestVerificationToken"]`)
+	if input.Length() == 0 {
+		input = doc.Find(`input[name="__RequestVerificationToken"]`)
+	}
+
+	token, _ := input.First().Attr("value")
+	if token == "" {
+		return "", ErrTokenTidakAda
+	}
+
+	return token, nil
+}
+
+// mengandungSalahSatu memeriksa apakah teks mengandung salah satu kata
+func mengandungSalahSatu(teks string, kata ...string) bool {
+	for _, k := range kata {
+		if strings.Contains(teks, k) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// formatTanggal adalah format tanggal pada file penghitung permintaan
+const formatTanggal = "2006-01-02"
+
+// jedaSimpanPenghitung adalah selang minimum antara dua penulisan file
+// penghitung permintaan
+const jedaSimpanPenghitung = 5 * time.Second
+
+// catatanPermintaan berisi jumlah permintaan pencarian ke KBBI pada satu hari
+type catatanPermintaan struct {
+	Tanggal string `json:"tanggal"`
+	Jumlah  int    `json:"jumlah"`
+}
+
+// LokasiPenghitung mengembalikan lokasi file penghitung permintaan harian,
+// yaitu permintaan.json di direktori yang sama dengan file kuki
+func (a *AutentikasiKBBI) LokasiPenghitung() string {
+	return filepath.Join(filepath.Dir(a.LokasiKuki), "permintaan.json")
+}
+
+// CatatPermintaan menambah jumlah permintaan pencarian hari ini
+//
+// KBBI membatasi jumlah pencarian harian per akun, sehingga penghitung
+// disimpan bersama kuki akun tersebut. Hitungan kembali ke nol pada
+// pergantian hari (waktu lokal). File ditulis paling sering sekali setiap
+// jedaSimpanPenghitung; permintaan di antaranya dikumpulkan dan disimpan
+// sekaligus, atau lebih awal melalui SimpanPenghitung.
+func (a *AutentikasiKBBI) CatatPermintaan() error {
+	a.muPermintaan.Lock()
+	defer a.muPermintaan.Unlock()
+
+	// Permintaan hari sebelumnya disimpan dulu agar tidak terhitung hari ini
+	tanggal := time.Now().Format(formatTanggal)
+	if a.tertunda.Tanggal != tanggal {
+		if err := a.simpanPenghitung(); err != nil {
+			return err
+		}
+		a.tertunda = catatanPermintaan{Tanggal: tanggal}
+	}
+	a.tertunda.Jumlah++
+
+	if jeda := jedaSimpanPenghitung - time.Since(a.terakhirSimpan); jeda > 0 {
+		if a.timerSimpan == nil {
+			a.timerSimpan = time.AfterFunc(jeda, func() { a.SimpanPenghitung() })
+		}
+		return nil
+	}
+	return a.simpanPenghitung()
+}
+
+// SimpanPenghitung menulis permintaan yang belum tersimpan ke file
+// penghitung, misalnya sebelum program berakhir
+func (a *AutentikasiKBBI) SimpanPenghitung() error {
+	a.muPermintaan.Lock()
+	defer a.muPermintaan.Unlock()
+
+	return a.simpanPenghitung()
+}
+
+// simpanPenghitung menambahkan permintaan tertunda ke isi file penghitung;
+// pemanggil harus memegang muPermintaan
+func (a *AutentikasiKBBI) simpanPenghitung() error {
+	if a.timerSimpan != nil {
+		a.timerSimpan.Stop()
+		a.timerSimpan = nil
+	}
+	if a.tertunda.Jumlah == 0 {
+		return nil
+	}
+
+	// File dibaca ulang agar hitungan dari proses lain tidak tertimpa.
+	// Hitungan hari yang sudah lewat dibuang jika proses lain sudah menulis
+	// hitungan untuk hari yang lebih baru.
+	catatan, ada := a.bacaFilePenghitung()
+	switch {
+	case ada && catatan.Tanggal > a.tertunda.Tanggal:
+		a.tertunda.Jumlah = 0
+		return nil
+	case !ada || catatan.Tanggal != a.tertunda.Tanggal:
+		catatan = catatanPermintaan{Tanggal: a.tertunda.Tanggal}
+	}
+	catatan.Jumlah += a.tertunda.Jumlah
+
+	data, err := json.MarshalIndent(catatan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("gagal mengenkode penghitung permintaan: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(a.LokasiPenghitung()), 0755); err != nil {
+		return fmt.Errorf("gagal membuat direktori: %w", err)
+	}
+	if err := os.WriteFile(a.LokasiPenghitung(), data, 0600); err != nil {
+		return fmt.Errorf("gagal menyimpan penghitung permintaan: %w", err)
+	}
+
+	a.tertunda.Jumlah = 0
+	a.terakhirSimpan = time.Now()
+	return nil
+}
+
+// PermintaanHariIni mengembalikan jumlah permintaan pencarian hari ini,
+// termasuk yang belum disimpan ke file
+func (a *AutentikasiKBBI) PermintaanHariIni() int {
+	a.muPermintaan.Lock()
+	defer a.muPermintaan.Unlock()
+
+	tanggal := time.Now().Format(formatTanggal)
+	jumlah := a.bacaPenghitung(tanggal).Jumlah
+	if a.tertunda.Tanggal == tanggal {
+		jumlah += a.tertunda.Jumlah
+	}
+	return jumlah
+}
+
+// bacaPenghitung membaca penghitung untuk tanggal tertentu; file yang tidak
+// ada, rusak, atau berisi tanggal lain dianggap bernilai nol
+func (a *AutentikasiKBBI) bacaPenghitung(tanggal string) catatanPermintaan {
+	catatan, ada := a.bacaFilePenghitung()
+	if !ada || catatan.Tanggal != tanggal {
+		return catatanPermintaan{Tanggal: tanggal}
+	}
+
+	return catatan
+}
+
+// bacaFilePenghitung membaca isi file penghitung apa adanya; ada bernilai
+// false jika file tidak ada atau rusak
+func (a *AutentikasiKBBI) bacaFilePenghitung() (catatan catatanPermintaan, ada bool) {
+	data, err := os.ReadFile(a.LokasiPenghitung())
+	if err != nil {
+		return catatan, false
+	}
+
+	if err := json.Unmarshal(data, &catatan); err != nil || catatan.Tanggal == "" {
+		return catatanPermintaan{}, false
+	}
+
+	return catatan, true
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCatatPermintaanBertahap(t *testing.T) {
+	a := &AutentikasiKBBI{LokasiKuki: filepath.Join(t.TempDir(), "kuki.json")}
+	hariIni := time.Now().Format(formatTanggal)
+
+	for i := 0; i < 10; i++ {
+		if err := a.CatatPermintaan(); err != nil {
+			t.Fatalf("CatatPermintaan: %v", err)
+		}
+	}
+
+	// Permintaan pertama langsung ditulis, sisanya menunggu jeda
+	if n := a.bacaPenghitung(hariIni).Jumlah; n != 1 {
+		t.Errorf("jumlah di file sebelum disimpan = %d, ingin 1", n)
+	}
+	if n := a.PermintaanHariIni(); n != 10 {
+		t.Errorf("PermintaanHariIni = %d, ingin 10", n)
+	}
+
+	if err := a.SimpanPenghitung(); err != nil {
+		t.Fatalf("SimpanPenghitung: %v", err)
+	}
+	if n := a.bacaPenghitung(hariIni).Jumlah; n != 10 {
+		t.Errorf("jumlah di file setelah disimpan = %d, ingin 10", n)
+	}
+
+	// Objek lain pada file yang sama melanjutkan hitungan, bukan menimpanya
+	b := &AutentikasiKBBI{LokasiKuki: a.LokasiKuki}
+	if err := b.CatatPermintaan(); err != nil {
+		t.Fatalf("CatatPermintaan: %v", err)
+	}
+	if n := a.PermintaanHariIni(); n != 11 {
+		t.Errorf("PermintaanHariIni setelah objek lain mencatat = %d, ingin 11", n)
+	}
+}
+
+func TestSimpanPenghitungBuangHariLewat(t *testing.T) {
+	a := &AutentikasiKBBI{LokasiKuki: filepath.Join(t.TempDir(), "kuki.json")}
+	hariIni := time.Now().Format(formatTanggal)
+	kemarin := time.Now().AddDate(0, 0, -1).Format(formatTanggal)
+
+	// Proses lain sudah mencatat tiga permintaan hari ini
+	b := &AutentikasiKBBI{LokasiKuki: a.LokasiKuki}
+	for i := 0; i < 3; i++ {
+		b.CatatPermintaan()
+	}
+	if err := b.SimpanPenghitung(); err != nil {
+		t.Fatalf("SimpanPenghitung: %v", err)
+	}
+
+	// Sisa hitungan kemarin tidak boleh menimpa catatan hari ini
+	a.tertunda = catatanPermintaan{Tanggal: kemarin, Jumlah: 5}
+	if err := a.SimpanPenghitung(); err != nil {
+		t.Fatalf("SimpanPenghitung: %v", err)
+	}
+	if n := a.bacaPenghitung(hariIni).Jumlah; n != 3 {
+		t.Errorf("jumlah hari ini setelah hitungan kemarin disimpan = %d, ingin 3", n)
+	}
+
+	// Pergantian hari di CatatPermintaan juga tidak menimpa catatan hari ini
+	a.tertunda = catatanPermintaan{Tanggal: kemarin, Jumlah: 5}
+	if err := a.CatatPermintaan(); err != nil {
+		t.Fatalf("CatatPermintaan: %v", err)
+	}
+	if err := a.SimpanPenghitung(); err != nil {
+		t.Fatalf("SimpanPenghitung: %v", err)
+	}
+	if n := a.bacaPenghitung(hariIni).Jumlah; n != 4 {
+		t.Errorf("jumlah hari ini setelah pergantian hari = %d, ingin 4", n)
+	}
+}
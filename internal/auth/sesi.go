@@ -0,0 +1,79 @@
+package auth
+
+import "strings"
+
+// Terautentikasi mengembalikan apakah sesi saat ini dalam keadaan masuk
+func (a *AutentikasiKBBI) Terautentikasi() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.terautentikasi
+}
+
+// EmailAkun mengembalikan email akun yang dipakai sesi ini, termasuk email
+// yang tercatat pada file kuki atau diambil dari sumber Kredensial
+func (a *AutentikasiKBBI) EmailAkun() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.Email
+}
+
+// Generasi mengembalikan nomor urut percobaan login terakhir
+//
+// Simpan nilai ini sebelum mengirim permintaan, lalu berikan ke
+// CekAutentikasiSejak dan PulihkanSesiSejak agar respons lama tidak menimpa
+// sesi yang sudah dipulihkan goroutine lain.
+func (a *AutentikasiKBBI) Generasi() uint64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.generasi
+}
+
+// CekAutentikasiSejak seperti CekAutentikasi, tetapi status sesi hanya
+// diperbarui jika belum ada login baru sejak generasi yang diberikan
+func (a *AutentikasiKBBI) CekAutentikasiSejak(generasi uint64, htmlContent string) bool {
+	masuk, pasti := statusHalaman(htmlContent)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !pasti {
+		return a.terautentikasi
+	}
+	if a.generasi == generasi {
+		a.terautentikasi = masuk
+	}
+	return masuk
+}
+
+// aturTerautentikasi mengubah status sesi
+func (a *AutentikasiKBBI) aturTerautentikasi(masuk bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.terautentikasi = masuk
+}
+
+// catatLogin menaikkan generasi dan menyimpan hasil percobaan login
+func (a *AutentikasiKBBI) catatLogin(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.generasi++
+	a.errLogin = err
+}
+
+// statusHalaman memeriksa apakah halaman KBBI ditampilkan untuk pengguna
+// yang sudah masuk; pasti bernilai false jika halaman tidak memuat tautan
+// masuk maupun form keluar, misalnya halaman kesalahan atau pemeliharaan
+func statusHalaman(htmlContent string) (masuk, pasti bool) {
+	switch {
+	case strings.Contains(htmlContent, "loginLink"):
+		return false, true
+	case strings.Contains(htmlContent, "logoutForm"):
+		return true, true
+	}
+	return false, false
+}
@@ -0,0 +1,180 @@
+// Package debug menyediakan penyimpanan HTML mentah dari KBBI untuk keperluan debug
+package debug
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/fetcher"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/model"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/parser"
+)
+
+// Mode menentukan kapan halaman disimpan
+type Mode int
+
+const (
+	// ModeSaatGagal menyimpan halaman hanya ketika hasil parsing kosong
+	// atau halaman kesalahan yang tidak terduga terdeteksi
+	ModeSaatGagal Mode = iota
+
+	// ModeSelalu menyimpan setiap halaman yang diambil
+	ModeSelalu
+)
+
+// Catatan merepresentasikan metadata yang disimpan bersama HTML mentah
+type Catatan struct {
+	Waktu            time.Time   `json:"waktu"`
+	Alasan           string      `json:"alasan"`
+	Kata             string      `json:"kata"`
+	URLPermintaan    string      `json:"url_permintaan"`
+	URL              string      `json:"url"`
+	KodeStatus       int         `json:"kode_status"`
+	HeaderPermintaan http.Header `json:"header_permintaan,omitempty"`
+	Header           http.Header `json:"header,omitempty"`
+	DariCache        bool        `json:"dari_cache"`
+	JumlahEntri      int         `json:"jumlah_entri"`
+	JumlahSaran      int         `json:"jumlah_saran"`
+	Kesalahan        string      `json:"kesalahan,omitempty"`
+	FileHTML         string      `json:"file_html"`
+
+	// Diagnostik berisi peringatan parser dari kesalahan mode ketat
+	Diagnostik []parser.Diagnostik `json:"diagnostik,omitempty"`
+}
+
+// PenyimpanDebug menyimpan halaman KBBI beserta hasil parsing ke direktori
+type PenyimpanDebug struct {
+	Direktori string
+	Mode      Mode
+}
+
+// BaruPenyimpanDebug membuat penyimpan debug baru
+func BaruPenyimpanDebug(direktori string, mode Mode) *PenyimpanDebug {
+	return &PenyimpanDebug{
+		Direktori: direktori,
+		Mode:      mode,
+	}
+}
+
+// Alasan menentukan alasan penyimpanan halaman, atau string kosong jika
+// halaman tidak perlu disimpan
+func (p *PenyimpanDebug) Alasan(definisi *model.Definisi, err error) string {
+	switch {
+	case errors.Is(err, parser.ErrTataLetak):
+		return "tata-letak"
+	case err != nil && !errors.Is(err, fetcher.ErrTidakDitemukan):
+		return "halaman-kesalahan"
+	case definisi == nil:
+		return "gagal-parsing"
+	case len(definisi.Entri) == 0 && len(definisi.SaranEntri) == 0:
+		return "tanpa-entri"
+	case p.Mode == ModeSelalu:
+		return "selalu"
+	default:
+		return ""
+	}
+}
+
+// Simpan menyimpan respons dan hasil parsing jika diperlukan sesuai mode
+//
+// Mengembalikan lokasi file metadata yang ditulis, atau string kosong jika
+// tidak ada yang disimpan.
+func (p *PenyimpanDebug) Simpan(respons *fetcher.Respons, definisi *model.Definisi, err error) (string, error) {
+	if p == nil || respons == nil {
+		return "", nil
+	}
+
+	alasan := p.Alasan(definisi, err)
+	if alasan == "" {
+		return "", nil
+	}
+
+	if errDir := os.MkdirAll(p.Direktori, 0755); errDir != nil {
+		return "", fmt.Errorf("gagal membuat direktori debug: %w", errDir)
+	}
+
+	waktu := time.Now()
+	namaDasar := fmt.Sprintf("%s-%s", waktu.Format("20060102-150405.000"), namaAman(respons.Kata))
+	fileHTML := filepath.Join(p.Direktori, namaDasar+".html")
+	fileCatatan := filepath.Join(p.Direktori, namaDasar+".json")
+
+	catatan := Catatan{
+		Waktu:            waktu,
+		Alasan:           alasan,
+		Kata:             respons.Kata,
+		URLPermintaan:    respons.URLPermintaan,
+		URL:              respons.URL,
+		KodeStatus:       respons.KodeStatus,
+		HeaderPermintaan: sensorHeader(respons.HeaderPermintaan),
+		Header:           sensorHeader(respons.Header),
+		DariCache:        respons.DariCache,
+		FileHTML:         filepath.Base(fileHTML),
+	}
+	if definisi != nil {
+		catatan.JumlahEntri = len(definisi.Entri)
+		catatan.JumlahSaran = len(definisi.SaranEntri)
+	}
+	if err != nil {
+		catatan.Kesalahan = err.Error()
+	}
+	var kesalahanParsing *parser.KesalahanParsing
+	if errors.As(err, &kesalahanParsing) {
+		catatan.Diagnostik = kesalahanParsing.Diagnostik
+	}
+
+	if errTulis := os.WriteFile(fileHTML, []byte(respons.HTML), 0644); errTulis != nil {
+		return "", fmt.Errorf("gagal menyimpan HTML debug: %w", errTulis)
+	}
+
+	data, errJSON := json.MarshalIndent(catatan, "", "  ")
+	if errJSON != nil {
+		return "", fmt.Errorf("gagal mengenkode catatan debug: %w", errJSON)
+	}
+
+	if errTulis := os.WriteFile(fileCatatan, data, 0644); errTulis != nil {
+		return "", fmt.Errorf("gagal menyimpan catatan debug: %w", errTulis)
+	}
+
+	return fileCatatan, nil
+}
+
+// headerRahasia berisi header yang nilainya disembunyikan pada file debug
+var headerRahasia = []string{"Set-Cookie", "Cookie", "Authorization", "Proxy-Authorization"}
+
+// sensorHeader menyalin header dan menyembunyikan nilai kuki serta
+// kredensial agar file debug aman dilampirkan pada laporan bug
+func sensorHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	salinan := header.Clone()
+	for _, nama := range headerRahasia {
+		if _, ada := salinan[nama]; ada {
+			salinan[nama] = []string{"(disensor)"}
+		}
+	}
+	return salinan
+}
+
+// namaAman mengubah kata menjadi nama file yang aman
+func namaAman(kata string) string {
+	nama := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' {
+			return r
+		}
+		return '_'
+	}, kata)
+
+	if nama == "" {
+		return "kosong"
+	}
+	return nama
+}
@@ -3,54 +3,162 @@ package fetcher
 
 import (
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"strings"
 	"time"
 	
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/auth"
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/cache"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
 )
 
 const (
-	HostKBBI = "https://kbbi.kemdikbud.go.id"
+	// HostKBBI adalah alamat bawaan KBBI Daring; alamat yang berlaku
+	// diatur melalui paket situs
+	HostKBBI = situs.HostBawaan
 )
 
+// JenisKesalahan merepresentasikan jenis kesalahan dari KBBI
+type JenisKesalahan int
+
+const (
+	JenisTidakDikenal JenisKesalahan = iota
+	JenisTidakDitemukan
+	JenisBatasSehari
+	JenisModaTerbatas
+	JenisTerjadiKesalahan
+	JenisAkunDibekukan
+	JenisStatusHTTP
+	JenisJaringan
+)
+
+// String mengembalikan nama jenis kesalahan
+func (j JenisKesalahan) String() string {
+	switch j {
+	case JenisTidakDitemukan:
+		return "TidakDitemukan"
+	case JenisBatasSehari:
+		return "BatasSehari"
+	case JenisModaTerbatas:
+		return "ModaTerbatas"
+	case JenisTerjadiKesalahan:
+		return "TerjadiKesalahan"
+	case JenisAkunDibekukan:
+		return "AkunDibekukan"
+	case JenisStatusHTTP:
+		return "StatusHTTP"
+	case JenisJaringan:
+		return "Jaringan"
+	default:
+		return "TidakDikenal"
+	}
+}
+
 // KesalahanKBBI merepresentasikan berbagai kesalahan dari KBBI
+//
+// Nilai sentinel (ErrTidakDitemukan, dst.) hanya berisi Jenis dan Pesan.
+// Kesalahan yang dikembalikan fetcher membawa konteks tambahan berupa kata
+// yang dicari, kode status HTTP, URL akhir, dan jumlah percobaan, dan tetap
+// cocok dengan sentinelnya melalui errors.Is.
 type KesalahanKBBI struct {
-	Jenis  string
-	Pesan  string
+	Jenis      JenisKesalahan
+	Pesan      string
+	Kata       string
+	KodeStatus int
+	URL        string
+	Percobaan  int
+	Err        error
 }
 
 func (e *KesalahanKBBI) Error() string {
-	return e.Pesan
+	pesan := e.Pesan
+	if e.Kata != "" {
+		pesan = fmt.Sprintf("%s (kata: %s)", pesan, e.Kata)
+	}
+	if e.Err != nil {
+		pesan = fmt.Sprintf("%s: %v", pesan, e.Err)
+	}
+	return pesan
+}
+
+// Unwrap mengembalikan error penyebab, jika ada
+func (e *KesalahanKBBI) Unwrap() error {
+	return e.Err
+}
+
+// Is mencocokkan kesalahan berdasarkan jenisnya sehingga
+// errors.Is(err, ErrBatasSehari) tetap berlaku meskipun err membawa konteks
+func (e *KesalahanKBBI) Is(target error) bool {
+	t, ok := target.(*KesalahanKBBI)
+	if !ok {
+		return false
+	}
+	return t.Jenis == e.Jenis
+}
+
+// denganKonteks membuat salinan kesalahan yang dilengkapi konteks permintaan
+func (e *KesalahanKBBI) denganKonteks(kata, urlAkhir string, kodeStatus int) *KesalahanKBBI {
+	salinan := *e
+	salinan.Kata = kata
+	salinan.URL = urlAkhir
+	salinan.KodeStatus = kodeStatus
+	return &salinan
 }
 
 var (
 	ErrTidakDitemukan = &KesalahanKBBI{
-		Jenis: "TidakDitemukan",
+		Jenis: JenisTidakDitemukan,
 		Pesan: "Entri tidak ditemukan dalam KBBI",
 	}
 	ErrBatasSehari = &KesalahanKBBI{
-		Jenis: "BatasSehari", 
+		Jenis: JenisBatasSehari,
 		Pesan: "Pencarian Anda telah mencapai batas maksimum dalam sehari",
 	}
 	ErrModaTerbatas = &KesalahanKBBI{
-		Jenis: "ModaTerbatas",
+		Jenis: JenisModaTerbatas,
 		Pesan: "KBBI Daring sedang dalam moda terbatas. Fitur pencarian dibatasi untuk pengguna umum",
 	}
 	ErrTerjadiKesalahan = &KesalahanKBBI{
-		Jenis: "TerjadiKesalahan",
+		Jenis: JenisTerjadiKesalahan,
 		Pesan: "Terjadi kesalahan saat memproses permintaan Anda",
 	}
 	ErrAkunDibekukan = &KesalahanKBBI{
-		Jenis: "AkunDibekukan", 
+		Jenis: JenisAkunDibekukan,
 		Pesan: "Akun ini sedang dibekukan, tidak dapat digunakan",
 	}
+	ErrStatusHTTP = &KesalahanKBBI{
+		Jenis: JenisStatusHTTP,
+		Pesan: "Server KBBI mengembalikan status yang tidak terduga",
+	}
+	ErrJaringan = &KesalahanKBBI{
+		Jenis: JenisJaringan,
+		Pesan: "Gagal terhubung ke KBBI",
+	}
 )
 
+// Respons merepresentasikan halaman yang diambil dari KBBI beserta metadatanya
+type Respons struct {
+	Kata             string
+	URLPermintaan    string
+	URL              string
+	KodeStatus       int
+	HeaderPermintaan http.Header
+	Header           http.Header
+	HTML             string
+	DariCache        bool
+}
+
+// htmlDari mengembalikan HTML dari respons, atau string kosong jika respons nil
+func htmlDari(respons *Respons) string {
+	if respons == nil {
+		return ""
+	}
+	return respons.HTML
+}
+
 // AmbilHalaman mengambil halaman dari KBBI berdasarkan kata pencarian
 func AmbilHalaman(kata string, autentikasi *auth.AutentikasiKBBI) (string, error) {
 	return AmbilHalamanDenganCache(kata, autentikasi, "", false)
@@ -58,11 +166,18 @@ func AmbilHalaman(kata string, autentikasi *auth.AutentikasiKBBI) (string, error
 
 // AmbilHalamanDenganCache mengambil halaman dari KBBI dengan dukungan cache
 func AmbilHalamanDenganCache(kata string, autentikasi *auth.AutentikasiKBBI, lokasiKuki string, tanpaCache bool) (string, error) {
+	respons, err := AmbilResponsDenganCache(kata, autentikasi, lokasiKuki, tanpaCache)
+	return htmlDari(respons), err
+}
+
+// AmbilResponsDenganCache mengambil halaman beserta metadata respons dengan dukungan cache
+func AmbilResponsDenganCache(kata string, autentikasi *auth.AutentikasiKBBI, lokasiKuki string, tanpaCache bool) (*Respons, error) {
 	var managerCache *cache.ManagerCache
 	var err error
 
-	// Inisialisasi cache manager jika cache digunakan
-	if !tanpaCache {
+	// Inisialisasi cache manager jika cache digunakan; cache hanya dipakai
+	// untuk KBBI Daring agar halaman dari host lain tidak tercampur
+	if !tanpaCache && situs.HostBawaanAktif() {
 		managerCache, err = cache.BaruManagerCache(lokasiKuki)
 		if err != nil {
 			// Jika gagal membuat cache manager, lanjutkan tanpa cache
@@ -73,24 +188,86 @@ func AmbilHalamanDenganCache(kata string, autentikasi *auth.AutentikasiKBBI, lok
 	// Coba ambil dari cache terlebih dahulu jika cache aktif
 	if managerCache != nil {
 		if htmlCache, found := managerCache.AmbilCache(kata); found {
-			return htmlCache, nil
+			urlLengkap := situs.URLEntri(kata)
+			return &Respons{
+				Kata:          kata,
+				URLPermintaan: urlLengkap,
+				URL:           urlLengkap,
+				KodeStatus:    http.StatusOK,
+				HTML:          htmlCache,
+				DariCache:     true,
+			}, nil
 		}
 	}
 
 	// Jika tidak ada di cache atau cache dinonaktifkan, ambil dari KBBI
-	html, err := ambilHalamanLangsung(kata, autentikasi)
+	respons, err := ambilHalamanLangsung(kata, autentikasi)
 	
 	// Simpan ke cache hanya jika berhasil (tidak ada error) dan cache aktif
 	if managerCache != nil && err == nil {
 		// Simpan ke cache, abaikan error penyimpanan
-		managerCache.SimpanCache(kata, html)
+		managerCache.SimpanCache(kata, respons.HTML)
 	}
 
-	return html, err
+	return respons, err
 }
 
 // ambilHalamanLangsung mengambil halaman langsung dari KBBI tanpa cache
-func ambilHalamanLangsung(kata string, autentikasi *auth.AutentikasiKBBI) (string, error) {
+//
+// Respons tetap dikembalikan ketika server menjawab dengan halaman kesalahan,
+// sehingga pemanggil bisa mengurai saran entri atau menyimpannya untuk debug.
+func ambilHalamanLangsung(kata string, autentikasi *auth.AutentikasiKBBI) (*Respons, error) {
+	// Catat generasi sesi sebelum mengirim permintaan, agar respons dengan
+	// kuki lama tidak menimpa sesi yang dipulihkan goroutine lain
+	var generasi uint64
+	masihMasuk := false
+	if autentikasi != nil {
+		generasi = autentikasi.Generasi()
+		masihMasuk = autentikasi.Terautentikasi()
+	}
+
+	respons, err := kirimPermintaan(kata, autentikasi)
+	if err != nil {
+		return respons, err
+	}
+	
+	// Update status autentikasi jika ada objek auth
+	if autentikasi != nil {
+		if !autentikasi.CekAutentikasiSejak(generasi, respons.HTML) && masihMasuk {
+			// Sesi berakhir di tengah jalan, coba login ulang (atau tunggu
+			// login ulang dari goroutine lain) lalu ulangi sekali
+			if autentikasi.PulihkanSesiSejak(generasi) == nil && autentikasi.Terautentikasi() {
+				generasi = autentikasi.Generasi()
+				respons, err = kirimPermintaan(kata, autentikasi)
+				if err != nil {
+					return respons, err
+				}
+				autentikasi.CekAutentikasiSejak(generasi, respons.HTML)
+			}
+		}
+	}
+
+	// Periksa kesalahan berdasarkan URL redirect atau konten
+	kesalahan := cekKesalahan(respons.URL, respons.HTML)
+
+	// Catat pencarian yang selesai dilayani KBBI Daring untuk penghitung
+	// harian akun, sekali per pencarian walaupun ada login ulang; abaikan
+	// error penyimpanan
+	if autentikasi != nil && situs.HostBawaanAktif() &&
+		(kesalahan == nil || kesalahan.Jenis == JenisTidakDitemukan) {
+		autentikasi.CatatPermintaan()
+	}
+
+	if kesalahan != nil {
+		// Kembalikan HTML untuk saran entri
+		return respons, kesalahan.denganKonteks(kata, respons.URL, respons.KodeStatus)
+	}
+
+	return respons, nil
+}
+
+// kirimPermintaan mengirim satu permintaan halaman entri ke KBBI
+func kirimPermintaan(kata string, autentikasi *auth.AutentikasiKBBI) (*Respons, error) {
 	var client *http.Client
 	
 	if autentikasi != nil {
@@ -102,39 +279,66 @@ func ambilHalamanLangsung(kata string, autentikasi *auth.AutentikasiKBBI) (strin
 	}
 
 	// Tentukan URL berdasarkan kata pencarian
-	lokasi := tentukanLokasi(kata)
-	urlLengkap := fmt.Sprintf("%s/%s", HostKBBI, lokasi)
+	lokasi := situs.LokasiEntri(kata)
+	urlLengkap := situs.URL(lokasi)
 
 	// Buat request dengan header yang wajar
 	req, err := http.NewRequest("GET", urlLengkap, nil)
 	if err != nil {
-		return "", fmt.Errorf("gagal membuat request: %w", err)
+		return nil, fmt.Errorf("gagal membuat request: %w", err)
 	}
 
 	// Set header untuk terlihat seperti browser biasa
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "id-ID,id;q=0.9,en;q=0.8")
-	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
-	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("Upgrade-Insecure-Requests", "1")
+	aturHeader(req)
 
 	// Tambahkan delay kecil untuk menghindari rate limiting
-	time.Sleep(500 * time.Millisecond)
+	time.Sleep(situs.Jeda())
 
 	// Kirim request
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("gagal mengambil halaman: %w", err)
+		kesalahan := ErrJaringan.denganKonteks(kata, urlLengkap, 0)
+		kesalahan.Err = err
+		return nil, kesalahan
 	}
 	defer resp.Body.Close()
 
+	respons := &Respons{
+		Kata:             kata,
+		URLPermintaan:    urlLengkap,
+		URL:              resp.Request.URL.String(),
+		KodeStatus:       resp.StatusCode,
+		HeaderPermintaan: req.Header.Clone(),
+		Header:           resp.Header.Clone(),
+	}
+
+	// Baca response body dengan dekompres jika perlu
+	htmlContent, err := bacaBody(resp)
+	if err != nil {
+		return respons, err
+	}
+	respons.HTML = htmlContent
+
 	// Periksa status code
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("server mengembalikan status code: %d", resp.StatusCode)
+		return respons, ErrStatusHTTP.denganKonteks(kata, respons.URL, resp.StatusCode)
 	}
 
-	// Baca response body dengan dekompres jika perlu
+	return respons, nil
+}
+
+// aturHeader mengatur header request agar terlihat seperti browser biasa
+func aturHeader(req *http.Request) {
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "id-ID,id;q=0.9,en;q=0.8")
+	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
+	req.Header.Set("Connection", "keep-alive")
+	req.Header.Set("Upgrade-Insecure-Requests", "1")
+}
+
+// bacaBody membaca response body dengan dekompres gzip jika perlu
+func bacaBody(resp *http.Response) (string, error) {
 	var reader io.Reader = resp.Body
 	if resp.Header.Get("Content-Encoding") == "gzip" {
 		gzipReader, err := gzip.NewReader(resp.Body)
@@ -150,44 +354,11 @@ func ambilHalamanLangsung(kata string, autentikasi *auth.AutentikasiKBBI) (strin
 		return "", fmt.Errorf("gagal membaca response body: %w", err)
 	}
 
-	htmlContent := string(body)
-	
-	// Update status autentikasi jika ada objek auth
-	if autentikasi != nil {
-		autentikasi.CekAutentikasi(htmlContent)
-	}
-
-	// Periksa kesalahan berdasarkan URL redirect atau konten
-	if err := cekKesalahan(resp.Request.URL.String(), htmlContent); err != nil {
-		return htmlContent, err // Kembalikan HTML untuk saran entri
-	}
-
-	return htmlContent, nil
-}
-
-// tentukanLokasi menentukan path URL berdasarkan kata pencarian
-func tentukanLokasi(kata string) string {
-	// Kasus khusus yang memerlukan pencarian via Cari/Hasil
-	kasusKhusus := []bool{
-		strings.Contains(kata, "."),
-		strings.Contains(kata, "?"),
-		strings.ToLower(kata) == "nul",
-		strings.ToLower(kata) == "bin",
-	}
-
-	for _, kondisi := range kasusKhusus {
-		if kondisi {
-			return fmt.Sprintf("Cari/Hasil?frasa=%s", url.QueryEscape(kata))
-		}
-	}
-
-	// Kasus normal - akses langsung ke entri (termasuk kata dengan spasi)
-	// Gunakan PathEscape untuk URL path, bukan QueryEscape
-	return fmt.Sprintf("entri/%s", url.PathEscape(kata))
+	return string(body), nil
 }
 
 // cekKesalahan memeriksa apakah ada kesalahan dalam response
-func cekKesalahan(urlResponse, htmlContent string) error {
+func cekKesalahan(urlResponse, htmlContent string) *KesalahanKBBI {
 	// Periksa URL redirect
 	if strings.Contains(urlResponse, "Beranda/Error") {
 		return ErrTerjadiKesalahan
@@ -211,15 +382,27 @@ func cekKesalahan(urlResponse, htmlContent string) error {
 	}
 	
 	// Periksa konten HTML untuk moda terbatas
-	if strings.Contains(htmlContent, "Moda terbatas sedang diaktifkan") || 
-	   strings.Contains(htmlContent, "pengguna tidak terdaftar tidak dapat dilayani") ||
-	   strings.Contains(htmlContent, "moda terbatas") {
+	if cekModaTerbatas(urlResponse, htmlContent) {
 		return ErrModaTerbatas
 	}
 
 	return nil
 }
 
+// cekModaTerbatas memeriksa apakah response menandakan moda terbatas,
+// baik melalui redirect maupun kalimat banner pada halaman
+//
+// Frasa "moda terbatas" saja tidak cukup karena dapat muncul pada isi
+// entri, misalnya makna atau contoh pemakaian.
+func cekModaTerbatas(urlResponse, htmlContent string) bool {
+	if strings.Contains(urlResponse, "Beranda/ModaTerbatas") {
+		return true
+	}
+
+	return strings.Contains(htmlContent, "Moda terbatas sedang diaktifkan") ||
+		strings.Contains(htmlContent, "pengguna tidak terdaftar tidak dapat dilayani")
+}
+
 // AmbilHalamanDenganRetry mengambil halaman dengan retry mechanism
 func AmbilHalamanDenganRetry(kata string, autentikasi *auth.AutentikasiKBBI, maxRetry int) (string, error) {
 	return AmbilHalamanDenganRetrydanCache(kata, autentikasi, maxRetry, "", false)
@@ -227,46 +410,74 @@ func AmbilHalamanDenganRetry(kata string, autentikasi *auth.AutentikasiKBBI, max
 
 // AmbilHalamanDenganRetrydanCache mengambil halaman dengan retry mechanism dan dukungan cache
 func AmbilHalamanDenganRetrydanCache(kata string, autentikasi *auth.AutentikasiKBBI, maxRetry int, lokasiKuki string, tanpaCache bool) (string, error) {
+	respons, err := AmbilResponsDenganRetrydanCache(kata, autentikasi, maxRetry, lokasiKuki, tanpaCache)
+	return htmlDari(respons), err
+}
+
+// AmbilResponsDenganRetrydanCache mengambil halaman beserta metadata respons
+// dengan retry mechanism dan dukungan cache
+//
+// Respons terakhir yang diterima tetap dikembalikan bersama error.
+func AmbilResponsDenganRetrydanCache(kata string, autentikasi *auth.AutentikasiKBBI, maxRetry int, lokasiKuki string, tanpaCache bool) (*Respons, error) {
+	var lastRespons *Respons
 	var lastErr error
 	
 	for i := 0; i < maxRetry; i++ {
-		html, err := AmbilHalamanDenganCache(kata, autentikasi, lokasiKuki, tanpaCache)
+		respons, err := AmbilResponsDenganCache(kata, autentikasi, lokasiKuki, tanpaCache)
 		if err != nil {
 			// Jika error adalah kesalahan KBBI tertentu, jangan retry
-			if kesalahanKBBI, ok := err.(*KesalahanKBBI); ok {
+			var kesalahanKBBI *KesalahanKBBI
+			if errors.As(err, &kesalahanKBBI) {
+				kesalahanKBBI.Percobaan = i + 1
 				switch kesalahanKBBI.Jenis {
-				case "TidakDitemukan", "BatasSehari", "ModaTerbatas", "AkunDibekukan":
-					return html, err
+				case JenisTidakDitemukan, JenisBatasSehari, JenisModaTerbatas, JenisAkunDibekukan:
+					return respons, err
 				}
 			}
 			
 			lastErr = err
+			if respons != nil {
+				lastRespons = respons
+			}
 			// Tambahkan delay yang semakin lama untuk retry
-			time.Sleep(time.Duration(i+1) * time.Second)
+			time.Sleep(time.Duration(i+1) * situs.JedaUlang())
 			continue
 		}
 		
-		return html, nil
+		return respons, nil
 	}
 	
-	return "", fmt.Errorf("gagal mengambil halaman setelah %d percobaan: %w", maxRetry, lastErr)
+	// Pertahankan jenis kesalahan terakhir agar tetap dapat diklasifikasikan
+	var kesalahanKBBI *KesalahanKBBI
+	if errors.As(lastErr, &kesalahanKBBI) {
+		kesalahanKBBI.Percobaan = maxRetry
+		return lastRespons, fmt.Errorf("gagal mengambil halaman setelah %d percobaan: %w", maxRetry, kesalahanKBBI)
+	}
+
+	return lastRespons, &KesalahanKBBI{
+		Jenis:     JenisTidakDikenal,
+		Pesan:     fmt.Sprintf("gagal mengambil halaman setelah %d percobaan", maxRetry),
+		Kata:      kata,
+		Percobaan: maxRetry,
+		Err:       lastErr,
+	}
 }
 
 // CekKoneksi memeriksa koneksi ke KBBI
 func CekKoneksi() error {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Get(HostKBBI)
+	status, err := CekStatus(nil)
 	if err != nil {
-		return fmt.Errorf("tidak dapat terhubung ke KBBI: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("KBBI mengembalikan status code: %d", resp.StatusCode)
+	if status.KodeStatus != http.StatusOK {
+		return &KesalahanKBBI{
+			Jenis:      JenisStatusHTTP,
+			Pesan:      fmt.Sprintf("KBBI mengembalikan status code: %d", status.KodeStatus),
+			KodeStatus: status.KodeStatus,
+			URL:        situs.Host(),
+		}
 	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,26 @@
+package fetcher
+
+import "testing"
+
+func TestCekModaTerbatas(t *testing.T) {
+	kasus := []struct {
+		nama  string
+		url   string
+		html  string
+		ingin bool
+	}{
+		{"redirect", "https://kbbi.kemdikbud.go.id/Beranda/ModaTerbatas", "", true},
+		{"banner aktif", "https://kbbi.kemdikbud.go.id/entri/moda", "<p>Moda terbatas sedang diaktifkan.</p>", true},
+		{"banner pengguna", "https://kbbi.kemdikbud.go.id/entri/moda", "<p>Saat ini pengguna tidak terdaftar tidak dapat dilayani.</p>", true},
+		{"frasa pada contoh", "https://kbbi.kemdikbud.go.id/entri/moda", "<li>cara: <i>aplikasi berjalan dalam moda terbatas</i></li>", false},
+		{"halaman biasa", "https://kbbi.kemdikbud.go.id/entri/rumah", "<li>bangunan untuk tempat tinggal</li>", false},
+	}
+
+	for _, k := range kasus {
+		t.Run(k.nama, func(t *testing.T) {
+			if hasil := cekModaTerbatas(k.url, k.html); hasil != k.ingin {
+				t.Errorf("cekModaTerbatas = %v, ingin %v", hasil, k.ingin)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package fetcher
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/auth"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
+)
+
+// StatusKBBI merepresentasikan kondisi layanan KBBI Daring
+//
+// Latensi dienkode JSON dalam nanodetik seperti time.Duration lainnya;
+// LatensiMs berisi nilai yang sama dalam milidetik.
+type StatusKBBI struct {
+	Terjangkau     bool          `json:"terjangkau"`
+	KodeStatus     int           `json:"kode_status,omitempty"`
+	Latensi        time.Duration `json:"latensi"`
+	LatensiMs      int64         `json:"latensi_ms"`
+	Host           string        `json:"host,omitempty"`
+	ModaTerbatas   bool          `json:"moda_terbatas"`
+	Terautentikasi bool          `json:"terautentikasi"`
+	Pengumuman     []string      `json:"pengumuman,omitempty"`
+	Kesalahan      string        `json:"kesalahan,omitempty"`
+}
+
+// CekStatus memeriksa status layanan KBBI Daring secara lengkap
+//
+// Status yang dikembalikan selalu terisi, termasuk ketika KBBI tidak dapat
+// dijangkau. Jika autentikasi diberikan, sesi juga diperiksa apakah masih
+// dalam keadaan masuk.
+func CekStatus(autentikasi *auth.AutentikasiKBBI) (*StatusKBBI, error) {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	if autentikasi != nil {
+		client = autentikasi.GetClient()
+	}
+
+	status := &StatusKBBI{}
+
+	req, err := http.NewRequest("GET", situs.Host(), nil)
+	if err != nil {
+		return status, fmt.Errorf("gagal membuat request: %w", err)
+	}
+	aturHeader(req)
+
+	mulai := time.Now()
+	resp, err := client.Do(req)
+	status.Latensi = time.Since(mulai)
+	status.LatensiMs = status.Latensi.Milliseconds()
+	if err != nil {
+		status.Kesalahan = err.Error()
+		return status, fmt.Errorf("tidak dapat terhubung ke KBBI: %w", err)
+	}
+	defer resp.Body.Close()
+
+	status.Terjangkau = true
+	status.KodeStatus = resp.StatusCode
+	status.Host = resp.Request.URL.Host
+
+	htmlContent, err := bacaBody(resp)
+	if err != nil {
+		status.Kesalahan = err.Error()
+		return status, err
+	}
+
+	status.ModaTerbatas = cekModaTerbatas(resp.Request.URL.String(), htmlContent)
+	status.Pengumuman = ambilPengumuman(htmlContent)
+
+	if autentikasi != nil {
+		status.Terautentikasi = autentikasi.CekAutentikasi(htmlContent)
+	}
+
+	return status, nil
+}
+
+// ambilPengumuman mengambil teks pengumuman situs dari halaman beranda
+func ambilPengumuman(htmlContent string) []string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil
+	}
+
+	var pengumuman []string
+	doc.Find(".alert, marquee").Each(func(i int, s *goquery.Selection) {
+		teks := strings.Join(strings.Fields(s.Text()), " ")
+		if teks != "" {
+			pengumuman = append(pengumuman, teks)
+		}
+	})
+
+	return pengumuman
+}
+
+// String mengembalikan representasi string dari StatusKBBI
+func (s *StatusKBBI) String() string {
+	var hasil []string
+
+	if s.Terjangkau {
+		hasil = append(hasil, fmt.Sprintf("Koneksi: terjangkau (%d, %s)",
+			s.KodeStatus, s.Latensi.Round(time.Millisecond)))
+	} else {
+		hasil = append(hasil, fmt.Sprintf("Koneksi: tidak terjangkau (%s)", s.Kesalahan))
+	}
+
+	if s.Host != "" {
+		hasil = append(hasil, fmt.Sprintf("Host: %s", s.Host))
+	}
+
+	hasil = append(hasil, fmt.Sprintf("Moda terbatas: %s", yaTidak(s.ModaTerbatas)))
+	hasil = append(hasil, fmt.Sprintf("Terautentikasi: %s", yaTidak(s.Terautentikasi)))
+
+	if len(s.Pengumuman) > 0 {
+		hasil = append(hasil, fmt.Sprintf("\nPengumuman\n%s",
+			strings.Join(s.Pengumuman, "\n")))
+	}
+
+	return strings.Join(hasil, "\n")
+}
+
+// yaTidak mengubah nilai boolean menjadi "ya" atau "tidak"
+func yaTidak(b bool) string {
+	if b {
+		return "ya"
+	}
+	return "tidak"
+}
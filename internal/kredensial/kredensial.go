@@ -0,0 +1,409 @@
+// Package kredensial menyediakan berbagai sumber email dan sandi akun KBBI
+// tanpa harus menuliskan sandi pada command line
+package kredensial
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
+	"golang.org/x/term"
+)
+
+const (
+	// NamaMesin adalah nama host KBBI Daring; .netrc dan pembantu
+	// kredensial memakai host dari alamat yang berlaku pada paket situs
+	NamaMesin = "kbbi.kemdikbud.go.id"
+
+	// EnvEmail adalah variabel lingkungan berisi email akun KBBI
+	EnvEmail = "KBBI_EMAIL"
+
+	// EnvSandi adalah variabel lingkungan berisi sandi akun KBBI
+	EnvSandi = "KBBI_SANDI"
+
+	// EnvPembantu adalah variabel lingkungan berisi perintah pembantu kredensial
+	EnvPembantu = "KBBI_PEMBANTU_KREDENSIAL"
+
+	// EnvFile adalah variabel lingkungan berisi lokasi file kredensial
+	EnvFile = "KBBI_FILE_KREDENSIAL"
+)
+
+// ErrTidakAda dikembalikan ketika sebuah sumber tidak menyediakan kredensial
+var ErrTidakAda = errors.New("kredensial KBBI tidak tersedia")
+
+// ErrIzinTerlaluLonggar dikembalikan ketika file kredensial dapat dibaca
+// oleh pengguna lain
+var ErrIzinTerlaluLonggar = errors.New("izin file kredensial terlalu longgar, gunakan chmod 600")
+
+// Kredensial berisi email dan sandi akun KBBI beserta asalnya
+type Kredensial struct {
+	Email  string `json:"email"`
+	Sandi  string `json:"sandi"`
+	Sumber string `json:"-"`
+}
+
+// Sumber menyediakan kredensial. Parameter email adalah email yang sudah
+// diketahui (boleh kosong) dan dipakai untuk memilih entri yang cocok.
+// Sumber boleh mengembalikan kredensial tanpa sandi sebagai petunjuk email.
+type Sumber func(email string) (*Kredensial, error)
+
+// Rantai mencoba setiap sumber sesuai urutan prioritas dan mengembalikan
+// kredensial lengkap pertama. Email dari sumber yang hanya menyediakan email
+// diteruskan ke sumber berikutnya.
+func Rantai(sumber ...Sumber) Sumber {
+	return func(email string) (*Kredensial, error) {
+		for _, s := range sumber {
+			k, err := s(email)
+			if errors.Is(err, ErrTidakAda) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if k.Email == "" {
+				k.Email = email
+			}
+			if k.Sandi == "" {
+				email = k.Email
+				continue
+			}
+			return k, nil
+		}
+		return nil, ErrTidakAda
+	}
+}
+
+// Tetap mengembalikan kredensial yang sudah diketahui, misalnya dari flag
+func Tetap(email, sandi, nama string) Sumber {
+	return func(string) (*Kredensial, error) {
+		if email == "" && sandi == "" {
+			return nil, ErrTidakAda
+		}
+		return &Kredensial{Email: email, Sandi: sandi, Sumber: nama}, nil
+	}
+}
+
+// DariEnv mengambil kredensial dari KBBI_EMAIL dan KBBI_SANDI
+//
+// Jika email sudah diketahui dan berbeda dengan KBBI_EMAIL, sumber ini
+// dilewati agar sandi akun lain tidak terpakai.
+func DariEnv() Sumber {
+	return func(email string) (*Kredensial, error) {
+		emailEnv := os.Getenv(EnvEmail)
+		if email != "" && emailEnv != "" && !strings.EqualFold(email, emailEnv) {
+			return nil, ErrTidakAda
+		}
+		return Tetap(emailEnv, os.Getenv(EnvSandi), "variabel lingkungan")(email)
+	}
+}
+
+// DariFile mengambil kredensial dari file JSON {"email": "...", "sandi": "..."}
+//
+// Jika lokasi kosong, dipakai KBBI_FILE_KREDENSIAL atau ~/.kbbi/kredensial.json.
+// File wajib hanya dapat dibaca pemiliknya (mis. chmod 600).
+func DariFile(lokasi string) Sumber {
+	return func(email string) (*Kredensial, error) {
+		lokasi := lokasi
+		if lokasi == "" {
+			lokasi = os.Getenv(EnvFile)
+		}
+		if lokasi == "" {
+			lokasi = LokasiFileBawaan()
+		}
+		if lokasi == "" {
+			return nil, ErrTidakAda
+		}
+
+		info, err := os.Stat(lokasi)
+		if os.IsNotExist(err) {
+			return nil, ErrTidakAda
+		}
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca file kredensial: %w", err)
+		}
+		if err := cekIzin(lokasi, info); err != nil {
+			return nil, err
+		}
+
+		data, err := os.ReadFile(lokasi)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca file kredensial: %w", err)
+		}
+
+		var k Kredensial
+		if err := json.Unmarshal(data, &k); err != nil {
+			return nil, fmt.Errorf("gagal membaca file kredensial %s: %w", lokasi, err)
+		}
+		if email != "" && k.Email != "" && !strings.EqualFold(email, k.Email) {
+			return nil, ErrTidakAda
+		}
+
+		k.Sumber = lokasi
+		return &k, nil
+	}
+}
+
+// LokasiFileBawaan mengembalikan lokasi default file kredensial
+func LokasiFileBawaan() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".kbbi", "kredensial.json")
+}
+
+// cekIzin memastikan file kredensial tidak dapat diakses pengguna lain
+func cekIzin(lokasi string, info os.FileInfo) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("%w: %s (%04o)", ErrIzinTerlaluLonggar, lokasi, info.Mode().Perm())
+	}
+	return nil
+}
+
+// DariNetrc mengambil kredensial dari entri machine dengan nama host KBBI
+// yang berlaku (bawaan kbbi.kemdikbud.go.id) pada file .netrc
+//
+// Jika lokasi kosong, dipakai $NETRC atau ~/.netrc (~/_netrc di Windows).
+func DariNetrc(lokasi string) Sumber {
+	return func(email string) (*Kredensial, error) {
+		lokasi := lokasi
+		if lokasi == "" {
+			lokasi = lokasiNetrc()
+		}
+		if lokasi == "" {
+			return nil, ErrTidakAda
+		}
+
+		data, err := os.ReadFile(lokasi)
+		if os.IsNotExist(err) {
+			return nil, ErrTidakAda
+		}
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca %s: %w", lokasi, err)
+		}
+
+		k := cariNetrc(string(data), alamatSitus().Hostname(), email)
+		if k == nil {
+			return nil, ErrTidakAda
+		}
+		k.Sumber = lokasi
+		return k, nil
+	}
+}
+
+// alamatSitus mengembalikan alamat KBBI yang berlaku, atau KBBI Daring jika
+// alamat tersebut tidak valid
+func alamatSitus() *url.URL {
+	if u, err := url.Parse(situs.Host()); err == nil && u.Host != "" {
+		return u
+	}
+	return &url.URL{Scheme: "https", Host: NamaMesin}
+}
+
+// lokasiNetrc mengembalikan lokasi default file .netrc
+func lokasiNetrc() string {
+	if lokasi := os.Getenv("NETRC"); lokasi != "" {
+		return lokasi
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(homeDir, "_netrc")
+	}
+	return filepath.Join(homeDir, ".netrc")
+}
+
+// entriNetrc merepresentasikan satu entri machine/default pada .netrc
+type entriNetrc struct {
+	mesin string
+	login string
+	sandi string
+}
+
+// tokenNetrc adalah satu kata pada file .netrc beserta nomor barisnya
+type tokenNetrc struct {
+	teks  string
+	baris int
+}
+
+// cariNetrc mencari entri mesin pada isi file .netrc, dengan entri
+// "default" sebagai cadangan
+func cariNetrc(isi, mesin, email string) *Kredensial {
+	semuaBaris := strings.Split(isi, "\n")
+	var token []tokenNetrc
+	for nomor, baris := range semuaBaris {
+		// Abaikan komentar
+		if strings.HasPrefix(strings.TrimSpace(baris), "#") {
+			continue
+		}
+		for _, teks := range strings.Fields(baris) {
+			token = append(token, tokenNetrc{teks: teks, baris: nomor})
+		}
+	}
+
+	var daftar []*entriNetrc
+	var saatIni *entriNetrc
+	for i := 0; i < len(token); i++ {
+		switch token[i].teks {
+		case "machine":
+			saatIni = &entriNetrc{}
+			daftar = append(daftar, saatIni)
+			if i+1 < len(token) {
+				i++
+				saatIni.mesin = token[i].teks
+			}
+		case "default":
+			saatIni = &entriNetrc{}
+			daftar = append(daftar, saatIni)
+		case "macdef":
+			// Isi makro dimulai setelah nama makro dan berlanjut sampai
+			// baris kosong pertama
+			akhir := token[i].baris + 1
+			for akhir < len(semuaBaris) && strings.TrimSpace(semuaBaris[akhir]) != "" {
+				akhir++
+			}
+			for i+1 < len(token) && token[i+1].baris <= akhir {
+				i++
+			}
+		case "login", "password", "account":
+			if i+1 >= len(token) {
+				continue
+			}
+			i++
+			if saatIni == nil {
+				continue
+			}
+			if token[i-1].teks == "login" {
+				saatIni.login = token[i].teks
+			} else if token[i-1].teks == "password" {
+				saatIni.sandi = token[i].teks
+			}
+		}
+	}
+
+	cocok := func(e *entriNetrc) bool {
+		return email == "" || strings.EqualFold(email, e.login)
+	}
+
+	for _, e := range daftar {
+		if e.mesin == mesin && cocok(e) {
+			return &Kredensial{Email: e.login, Sandi: e.sandi}
+		}
+	}
+	for _, e := range daftar {
+		if e.mesin == "" && cocok(e) {
+			return &Kredensial{Email: e.login, Sandi: e.sandi}
+		}
+	}
+
+	return nil
+}
+
+// DariPembantu menjalankan perintah pembantu kredensial eksternal
+//
+// Perintah menerima "host=..." berisi host KBBI yang berlaku (bawaan
+// kbbi.kemdikbud.go.id), serta "username=..." jika email diketahui, melalui
+// stdin, lalu menulis baris "email=..." atau "username=...", dan "sandi=..."
+// atau "password=..." ke stdout, mirip dengan git credential helper. Jika perintah kosong, dipakai
+// KBBI_PEMBANTU_KREDENSIAL.
+func DariPembantu(perintah string) Sumber {
+	return func(email string) (*Kredensial, error) {
+		perintah := perintah
+		if perintah == "" {
+			perintah = os.Getenv(EnvPembantu)
+		}
+		if perintah == "" {
+			return nil, ErrTidakAda
+		}
+
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/C", perintah)
+		} else {
+			cmd = exec.Command("sh", "-c", perintah)
+		}
+
+		alamat := alamatSitus()
+		masukan := fmt.Sprintf("protocol=%s\nhost=%s\n", alamat.Scheme, alamat.Host)
+		if email != "" {
+			masukan += fmt.Sprintf("username=%s\n", email)
+		}
+		cmd.Stdin = strings.NewReader(masukan + "\n")
+		cmd.Stderr = os.Stderr
+
+		keluaran, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("pembantu kredensial gagal: %w", err)
+		}
+
+		k := &Kredensial{Sumber: "pembantu kredensial"}
+		pemindai := bufio.NewScanner(bytes.NewReader(keluaran))
+		for pemindai.Scan() {
+			kunci, nilai, ada := strings.Cut(pemindai.Text(), "=")
+			if !ada {
+				continue
+			}
+			switch strings.TrimSpace(kunci) {
+			case "email", "username", "login":
+				k.Email = nilai
+			case "sandi", "password":
+				k.Sandi = nilai
+			}
+		}
+
+		if k.Email == "" && k.Sandi == "" {
+			return nil, ErrTidakAda
+		}
+		return k, nil
+	}
+}
+
+// DariPrompt meminta email (jika belum diketahui) dan sandi dari terminal;
+// sandi dibaca tanpa ditampilkan
+func DariPrompt() Sumber {
+	return func(email string) (*Kredensial, error) {
+		fd := int(os.Stdin.Fd())
+		if !term.IsTerminal(fd) {
+			return nil, ErrTidakAda
+		}
+
+		if email == "" {
+			fmt.Fprint(os.Stderr, "Masukkan email: ")
+			baris, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil && baris == "" {
+				return nil, fmt.Errorf("gagal membaca email: %w", err)
+			}
+			email = strings.TrimSpace(baris)
+		}
+
+		fmt.Fprint(os.Stderr, "Masukkan kata sandi: ")
+		sandi, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca kata sandi: %w", err)
+		}
+
+		return &Kredensial{Email: email, Sandi: string(sandi), Sumber: "terminal"}, nil
+	}
+}
+
+// Bawaan mengembalikan rantai sumber non-interaktif sesuai urutan prioritas:
+// variabel lingkungan, pembantu kredensial, file kredensial, lalu .netrc
+func Bawaan() Sumber {
+	return Rantai(DariEnv(), DariPembantu(""), DariFile(""), DariNetrc(""))
+}
@@ -0,0 +1,211 @@
+package kredensial
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
+)
+
+func TestCariNetrc(t *testing.T) {
+	kasus := []struct {
+		nama  string
+		isi   string
+		email string
+		ingin *Kredensial
+	}{
+		{
+			nama:  "mesin cocok",
+			isi:   "machine contoh.id login a password x\nmachine kbbi.kemdikbud.go.id login b password y\n",
+			ingin: &Kredensial{Email: "b", Sandi: "y"},
+		},
+		{
+			nama:  "default sebagai cadangan",
+			isi:   "machine contoh.id login a password x\ndefault login c password z\n",
+			ingin: &Kredensial{Email: "c", Sandi: "z"},
+		},
+		{
+			nama:  "mesin didahulukan dari default",
+			isi:   "default login c password z\nmachine kbbi.kemdikbud.go.id login b password y\n",
+			ingin: &Kredensial{Email: "b", Sandi: "y"},
+		},
+		{
+			nama:  "email memilih entri",
+			isi:   "machine kbbi.kemdikbud.go.id login a password x\nmachine kbbi.kemdikbud.go.id login B@contoh.id password y\n",
+			email: "b@contoh.id",
+			ingin: &Kredensial{Email: "B@contoh.id", Sandi: "y"},
+		},
+		{
+			nama:  "email tidak cocok",
+			isi:   "machine kbbi.kemdikbud.go.id login a password x\n",
+			email: "b@contoh.id",
+		},
+		{
+			nama:  "komentar diabaikan",
+			isi:   "# machine kbbi.kemdikbud.go.id login a password x\nmachine kbbi.kemdikbud.go.id\n  login b\n  password y\n",
+			ingin: &Kredensial{Email: "b", Sandi: "y"},
+		},
+		{
+			nama: "isi macdef dilewati sampai baris kosong",
+			isi: "macdef init\ncd /pub\nmachine kbbi.kemdikbud.go.id login palsu password palsu\n\n" +
+				"machine kbbi.kemdikbud.go.id login b password y\n",
+			ingin: &Kredensial{Email: "b", Sandi: "y"},
+		},
+		{
+			nama: "macdef di akhir entri",
+			isi: "machine kbbi.kemdikbud.go.id login b password y macdef init\nlogin palsu\npassword palsu\n\n" +
+				"default login c password z\n",
+			email: "b",
+			ingin: &Kredensial{Email: "b", Sandi: "y"},
+		},
+		{
+			nama: "macdef tanpa baris kosong menelan sisa file",
+			isi:  "macdef init\nmachine kbbi.kemdikbud.go.id login palsu password palsu\n",
+		},
+		{
+			nama: "tanpa entri",
+			isi:  "machine contoh.id login a password x\n",
+		},
+	}
+
+	for _, k := range kasus {
+		t.Run(k.nama, func(t *testing.T) {
+			hasil := cariNetrc(k.isi, NamaMesin, k.email)
+			switch {
+			case k.ingin == nil && hasil != nil:
+				t.Errorf("cariNetrc = %+v, ingin nil", *hasil)
+			case k.ingin != nil && hasil == nil:
+				t.Errorf("cariNetrc = nil, ingin %+v", *k.ingin)
+			case k.ingin != nil && *hasil != *k.ingin:
+				t.Errorf("cariNetrc = %+v, ingin %+v", *hasil, *k.ingin)
+			}
+		})
+	}
+}
+
+func TestDariNetrcMengikutiSitus(t *testing.T) {
+	lokasi := filepath.Join(t.TempDir(), "netrc")
+	isi := "machine kbbi.kemdikbud.go.id login a password x\nmachine 127.0.0.1 login b password y\n"
+	if err := os.WriteFile(lokasi, []byte(isi), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := DariNetrc(lokasi)("")
+	if err != nil || k.Email != "a" {
+		t.Fatalf("host bawaan: DariNetrc = %+v, %v; ingin login a", k, err)
+	}
+
+	pulihkan := situs.Atur(situs.Pengaturan{Host: "http://127.0.0.1:8080"})
+	defer pulihkan()
+	k, err = DariNetrc(lokasi)("")
+	if err != nil || k.Email != "b" {
+		t.Fatalf("host lain: DariNetrc = %+v, %v; ingin login b", k, err)
+	}
+}
+
+func TestDariPembantuMengikutiSitus(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pembantu uji memakai sh")
+	}
+
+	pulihkan := situs.Atur(situs.Pengaturan{Host: "http://127.0.0.1:8080"})
+	defer pulihkan()
+
+	// Pembantu mengembalikan host yang diterimanya sebagai email
+	k, err := DariPembantu(`sed -n 's/^host=/email=/p'; echo sandi=rahasia`)("")
+	if err != nil {
+		t.Fatalf("DariPembantu: %v", err)
+	}
+	if k.Email != "127.0.0.1:8080" {
+		t.Errorf("host yang dikirim = %q, ingin \"127.0.0.1:8080\"", k.Email)
+	}
+}
+
+func TestDariFileIzin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("izin file tidak diperiksa di Windows")
+	}
+
+	lokasi := filepath.Join(t.TempDir(), "kredensial.json")
+	if err := os.WriteFile(lokasi, []byte(`{"email": "a@contoh.id", "sandi": "x"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Izin diatur ulang karena WriteFile mengikuti umask
+	if err := os.Chmod(lokasi, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := DariFile(lokasi)(""); !errors.Is(err, ErrIzinTerlaluLonggar) {
+		t.Errorf("0644: error = %v, ingin ErrIzinTerlaluLonggar", err)
+	}
+
+	if err := os.Chmod(lokasi, 0600); err != nil {
+		t.Fatal(err)
+	}
+	k, err := DariFile(lokasi)("")
+	if err != nil {
+		t.Fatalf("0600: %v", err)
+	}
+	if k.Email != "a@contoh.id" || k.Sandi != "x" || k.Sumber != lokasi {
+		t.Errorf("0600: kredensial = %+v", *k)
+	}
+
+	if _, err := DariFile(lokasi)("b@contoh.id"); !errors.Is(err, ErrTidakAda) {
+		t.Errorf("email lain: error = %v, ingin ErrTidakAda", err)
+	}
+}
+
+func TestRantai(t *testing.T) {
+	var urutan []string
+	sumber := func(nama string, k *Kredensial, err error) Sumber {
+		return func(email string) (*Kredensial, error) {
+			urutan = append(urutan, nama+":"+email)
+			if k == nil {
+				return nil, err
+			}
+			salinan := *k
+			return &salinan, err
+		}
+	}
+
+	// Sumber pertama yang lengkap menang; petunjuk email diteruskan
+	urutan = nil
+	k, err := Rantai(
+		sumber("kosong", nil, ErrTidakAda),
+		sumber("petunjuk", &Kredensial{Email: "a@contoh.id"}, nil),
+		sumber("lengkap", &Kredensial{Sandi: "x", Sumber: "lengkap"}, nil),
+		sumber("cadangan", &Kredensial{Email: "b@contoh.id", Sandi: "y"}, nil),
+	)("")
+	if err != nil {
+		t.Fatalf("Rantai: %v", err)
+	}
+	if k.Email != "a@contoh.id" || k.Sandi != "x" || k.Sumber != "lengkap" {
+		t.Errorf("kredensial = %+v, ingin email petunjuk dengan sandi dari sumber lengkap", *k)
+	}
+	if ingin := "kosong: petunjuk: lengkap:a@contoh.id"; strings.Join(urutan, " ") != ingin {
+		t.Errorf("urutan = %q, ingin %q", strings.Join(urutan, " "), ingin)
+	}
+
+	// Error selain ErrTidakAda menghentikan rantai
+	urutan = nil
+	gagal := errors.New("gagal")
+	_, err = Rantai(
+		sumber("rusak", nil, gagal),
+		sumber("cadangan", &Kredensial{Email: "b@contoh.id", Sandi: "y"}, nil),
+	)("c@contoh.id")
+	if !errors.Is(err, gagal) {
+		t.Errorf("error = %v, ingin error sumber rusak", err)
+	}
+	if len(urutan) != 1 {
+		t.Errorf("urutan = %q, ingin berhenti di sumber rusak", urutan)
+	}
+
+	// Hanya petunjuk email tanpa sandi berarti tidak ada kredensial
+	if _, err := Rantai(sumber("petunjuk", &Kredensial{Email: "a@contoh.id"}, nil))(""); !errors.Is(err, ErrTidakAda) {
+		t.Errorf("hanya petunjuk: error = %v, ingin ErrTidakAda", err)
+	}
+}
@@ -0,0 +1,49 @@
+package model
+
+// GayaPenekanan merepresentasikan gaya penekanan di dalam contoh
+type GayaPenekanan string
+
+const (
+	// GayaMiring untuk teks bercetak miring (i, em)
+	GayaMiring GayaPenekanan = "miring"
+
+	// GayaTebal untuk teks bercetak tebal (b, strong)
+	GayaTebal GayaPenekanan = "tebal"
+)
+
+// Penekanan merepresentasikan bagian contoh yang diberi penekanan
+//
+// Awal dan Akhir adalah posisi byte pada Contoh.Teks.
+type Penekanan struct {
+	Teks  string        `json:"teks"`
+	Awal  int           `json:"awal"`
+	Akhir int           `json:"akhir"`
+	Gaya  GayaPenekanan `json:"gaya"`
+}
+
+// Contoh merepresentasikan satu contoh pemakaian makna
+type Contoh struct {
+	// Teks adalah contoh sebagaimana tertulis di KBBI, dengan "--" atau "~"
+	// sebagai pengganti entri
+	Teks string `json:"teks"`
+
+	// TeksLengkap adalah contoh dengan pengganti entri diganti entrinya
+	TeksLengkap string `json:"teks_lengkap"`
+
+	// Penekanan berisi bagian contoh yang bercetak miring atau tebal
+	Penekanan []Penekanan `json:"penekanan,omitempty"`
+}
+
+// String mengembalikan teks contoh sebagaimana tertulis di KBBI
+func (c Contoh) String() string {
+	return c.Teks
+}
+
+// TeksContoh mengambil teks setiap contoh sebagaimana tertulis di KBBI
+func TeksContoh(contoh []Contoh) []string {
+	teks := make([]string, len(contoh))
+	for i, c := range contoh {
+		teks[i] = c.Teks
+	}
+	return teks
+}
@@ -0,0 +1,153 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JenisKelas merepresentasikan kelas kata KBBI secara baku
+type JenisKelas int
+
+const (
+	// KelasTidakDikenal untuk kode kelas kata yang tidak dikenali
+	KelasTidakDikenal JenisKelas = iota
+
+	// KelasNomina adalah kata benda (n)
+	KelasNomina
+
+	// KelasVerba adalah kata kerja (v)
+	KelasVerba
+
+	// KelasAdjektiva adalah kata sifat (a)
+	KelasAdjektiva
+
+	// KelasAdverbia adalah kata keterangan (adv)
+	KelasAdverbia
+
+	// KelasNumeralia adalah kata bilangan (num)
+	KelasNumeralia
+
+	// KelasPartikel adalah kata tugas seperti kata depan dan kata sambung (p)
+	KelasPartikel
+
+	// KelasPronomina adalah kata ganti (pron)
+	KelasPronomina
+
+	// KelasPrakategorial adalah bentuk yang tidak dipakai dalam bentuk
+	// dasarnya
+	KelasPrakategorial
+)
+
+// infoKelas berisi kode dan nama baku setiap kelas kata
+var infoKelas = map[JenisKelas]struct {
+	kode, nama, namaInggris string
+}{
+	KelasNomina:        {"n", "Nomina", "Noun"},
+	KelasVerba:         {"v", "Verba", "Verb"},
+	KelasAdjektiva:     {"a", "Adjektiva", "Adjective"},
+	KelasAdverbia:      {"adv", "Adverbia", "Adverb"},
+	KelasNumeralia:     {"num", "Numeralia", "Numeral"},
+	KelasPartikel:      {"p", "Partikel", "Particle"},
+	KelasPronomina:     {"pron", "Pronomina", "Pronoun"},
+	KelasPrakategorial: {"pra", "Prakategorial", "Precategorial"},
+}
+
+// kodeKelas memetakan kode dan nama kelas kata yang ditemui di halaman ke
+// jenisnya (dalam huruf kecil)
+var kodeKelas = map[string]JenisKelas{}
+
+func init() {
+	for jenis, info := range infoKelas {
+		kodeKelas[info.kode] = jenis
+		kodeKelas[strings.ToLower(info.nama)] = jenis
+	}
+
+	// Ejaan lain yang ditemui di halaman KBBI
+	kodeKelas["adj"] = KelasAdjektiva
+	kodeKelas["prakat"] = KelasPrakategorial
+}
+
+// CariKelas mencari jenis kelas kata dari kode atau namanya, misalnya "v",
+// "Verba", atau "Verba: kata kerja"
+func CariKelas(kode string) JenisKelas {
+	kode, _, _ = strings.Cut(kode, ":")
+	return kodeKelas[strings.ToLower(strings.TrimSpace(kode))]
+}
+
+// Kode mengembalikan kode baku kelas kata, misalnya "v"
+func (j JenisKelas) Kode() string {
+	return infoKelas[j].kode
+}
+
+// Nama mengembalikan nama baku kelas kata dalam bahasa Indonesia
+func (j JenisKelas) Nama() string {
+	if info, ada := infoKelas[j]; ada {
+		return info.nama
+	}
+	return "Tidak dikenal"
+}
+
+// NamaInggris mengembalikan nama kelas kata dalam bahasa Inggris
+func (j JenisKelas) NamaInggris() string {
+	if info, ada := infoKelas[j]; ada {
+		return info.namaInggris
+	}
+	return "Unknown"
+}
+
+// String mengembalikan nama kelas kata dalam huruf kecil, misalnya "verba"
+func (j JenisKelas) String() string {
+	if info, ada := infoKelas[j]; ada {
+		return strings.ToLower(info.nama)
+	}
+	return "tidak_dikenal"
+}
+
+// MarshalText mengenkode jenis kelas kata sebagai namanya
+func (j JenisKelas) MarshalText() ([]byte, error) {
+	return []byte(j.String()), nil
+}
+
+// UnmarshalText membaca jenis kelas kata dari namanya
+func (j *JenisKelas) UnmarshalText(teks []byte) error {
+	if string(teks) == KelasTidakDikenal.String() {
+		*j = KelasTidakDikenal
+		return nil
+	}
+	for jenis, info := range infoKelas {
+		if strings.ToLower(info.nama) == string(teks) {
+			*j = jenis
+			return nil
+		}
+	}
+	return fmt.Errorf("kelas kata tidak dikenal: %q", teks)
+}
+
+// PunyaKelas memeriksa apakah makna atau salah satu makna anaknya
+// berkelas kata tertentu
+func (m *Makna) PunyaKelas(jenis JenisKelas) bool {
+	for _, k := range m.Kelas {
+		if k.Jenis == jenis {
+			return true
+		}
+	}
+	for i := range m.Anak {
+		if m.Anak[i].PunyaKelas(jenis) {
+			return true
+		}
+	}
+	return false
+}
+
+// PunyaKelas memeriksa apakah salah satu makna entri berkelas kata
+// tertentu
+//
+// Contoh: entri.PunyaKelas(model.KelasVerba)
+func (e *Entri) PunyaKelas(jenis JenisKelas) bool {
+	for i := range e.Makna {
+		if e.Makna[i].PunyaKelas(jenis) {
+			return true
+		}
+	}
+	return false
+}
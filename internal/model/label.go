@@ -0,0 +1,229 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+// KategoriLabel merepresentasikan golongan label pemakaian
+type KategoriLabel int
+
+const (
+	// KategoriTidakDikenal untuk label yang tidak ada dalam daftar label
+	KategoriTidakDikenal KategoriLabel = iota
+
+	// KategoriRagam untuk ragam bahasa (cak, hor, kas, ark, kl)
+	KategoriRagam
+
+	// KategoriKiasan untuk makna kiasan (ki)
+	KategoriKiasan
+
+	// KategoriBidang untuk bidang ilmu atau kegiatan (Dok, Ling, dll)
+	KategoriBidang
+
+	// KategoriBahasaDaerah untuk bahasa daerah asal (Jw, Sd, Mk, dll)
+	KategoriBahasaDaerah
+
+	// KategoriBahasaAsing untuk bahasa asing asal (Ar, Bld, Ing, dll)
+	KategoriBahasaAsing
+
+	// KategoriBentuk untuk keterangan bentuk kata (akr, sing, dll)
+	KategoriBentuk
+)
+
+// String mengembalikan nama kategori label
+func (k KategoriLabel) String() string {
+	switch k {
+	case KategoriRagam:
+		return "ragam"
+	case KategoriKiasan:
+		return "kiasan"
+	case KategoriBidang:
+		return "bidang"
+	case KategoriBahasaDaerah:
+		return "bahasa_daerah"
+	case KategoriBahasaAsing:
+		return "bahasa_asing"
+	case KategoriBentuk:
+		return "bentuk"
+	default:
+		return "tidak_dikenal"
+	}
+}
+
+// MarshalText mengenkode kategori label sebagai namanya
+func (k KategoriLabel) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText membaca kategori label dari namanya
+func (k *KategoriLabel) UnmarshalText(teks []byte) error {
+	for kategori := KategoriTidakDikenal; kategori <= KategoriBentuk; kategori++ {
+		if kategori.String() == string(teks) {
+			*k = kategori
+			return nil
+		}
+	}
+	return fmt.Errorf("kategori label tidak dikenal: %q", teks)
+}
+
+// Label merepresentasikan label pemakaian pada makna
+type Label struct {
+	Kode     string        `json:"kode"`
+	Nama     string        `json:"nama"`
+	Kategori KategoriLabel `json:"kategori"`
+}
+
+// String mengembalikan kode label
+func (l Label) String() string {
+	return l.Kode
+}
+
+// daftarLabel berisi label pemakaian KBBI yang dikenali, dikunci dengan
+// kodenya (peka huruf besar-kecil: "ark" arkais, "Ark" arkeologi)
+//
+// Daftar ini mengikuti daftar singkatan pada petunjuk pemakaian KBBI. Label
+// di luar daftar tetap disimpan dengan KategoriTidakDikenal dan hanya
+// menghasilkan diagnostik TingkatInfo.
+var daftarLabel = map[string]Label{}
+
+func init() {
+	tambah := func(kategori KategoriLabel, pasangan ...string) {
+		for i := 0; i+1 < len(pasangan); i += 2 {
+			daftarLabel[pasangan[i]] = Label{Kode: pasangan[i], Nama: pasangan[i+1], Kategori: kategori}
+		}
+	}
+
+	tambah(KategoriRagam,
+		"ark", "arkais",
+		"cak", "cakapan",
+		"hor", "hormat",
+		"kas", "kasar",
+		"kl", "klasik",
+		"sas", "sastra",
+	)
+	tambah(KategoriKiasan,
+		"ki", "kiasan",
+	)
+	tambah(KategoriBentuk,
+		"akr", "akronim",
+		"sing", "singkatan",
+		"kp", "kependekan",
+		"ukp", "ungkapan",
+	)
+	tambah(KategoriBidang,
+		"Adm", "Administrasi",
+		"Anat", "Anatomi",
+		"Antr", "Antropologi",
+		"Ark", "Arkeologi",
+		"Ars", "Arsitektur",
+		"Astrol", "Astrologi",
+		"Astron", "Astronomi",
+		"Bio", "Biologi",
+		"Bot", "Botani",
+		"Bud", "Buddha",
+		"Dag", "Perdagangan",
+		"Dik", "Pendidikan",
+		"Dok", "Kedokteran",
+		"Ek", "Ekonomi",
+		"Elek", "Elektronika",
+		"Far", "Farmasi",
+		"Fil", "Filsafat",
+		"Fis", "Fisika",
+		"Fot", "Fotografi",
+		"Geo", "Geografi",
+		"Geol", "Geologi",
+		"Graf", "Grafika",
+		"Hidm", "Hidrometeorologi",
+		"Hind", "Hindu",
+		"Hut", "Kehutanan",
+		"Huk", "Hukum",
+		"Ikn", "Perikanan",
+		"Isl", "Islam",
+		"Kap", "Perkapalan",
+		"Kat", "Katolik",
+		"Keu", "Keuangan",
+		"Kim", "Kimia",
+		"Kom", "Komunikasi",
+		"Komp", "Komputer",
+		"Kris", "Kristen",
+		"Lay", "Pelayaran",
+		"Ling", "Linguistik",
+		"Man", "Manajemen",
+		"Mat", "Matematika",
+		"Met", "Meteorologi",
+		"Mil", "Militer",
+		"Min", "Mineralogi",
+		"Mus", "Musik",
+		"Olr", "Olahraga",
+		"Pet", "Perminyakan",
+		"Pol", "Politik",
+		"Psi", "Psikologi",
+		"Sen", "Kesenian",
+		"Sos", "Sosiologi",
+		"Stat", "Statistik",
+		"Tan", "Pertanian",
+		"Tek", "Teknik",
+		"Tern", "Peternakan",
+		"Zool", "Zoologi",
+	)
+	tambah(KategoriBahasaDaerah,
+		"Ac", "Aceh",
+		"Bl", "Bali",
+		"Bt", "Batak",
+		"Bug", "Bugis",
+		"Bjr", "Banjar",
+		"Dy", "Dayak",
+		"Jk", "Jakarta",
+		"Jw", "Jawa",
+		"Mdr", "Madura",
+		"Mk", "Minangkabau",
+		"Mkl", "Makassar",
+		"Plb", "Palembang",
+		"Sas", "Sasak",
+		"Sd", "Sunda",
+	)
+	tambah(KategoriBahasaAsing,
+		"Ar", "Arab",
+		"Bld", "Belanda",
+		"Cn", "Cina",
+		"Hin", "Hindi",
+		"Ibr", "Ibrani",
+		"Ing", "Inggris",
+		"It", "Italia",
+		"Jm", "Jerman",
+		"Jp", "Jepang",
+		"Lat", "Latin",
+		"Prn", "Prancis",
+		"Prs", "Persia",
+		"Prt", "Portugis",
+		"Skt", "Sanskerta",
+		"Sp", "Spanyol",
+		"Tm", "Tamil",
+		"Yn", "Yunani",
+	)
+}
+
+// CariLabel mencari label pada daftar label yang dikenali
+func CariLabel(kode string) (Label, bool) {
+	label, ada := daftarLabel[kode]
+	return label, ada
+}
+
+// DaftarLabel mengembalikan semua label yang dikenali, diurutkan
+// berdasarkan kategori lalu kode
+func DaftarLabel() []Label {
+	daftar := make([]Label, 0, len(daftarLabel))
+	for _, label := range daftarLabel {
+		daftar = append(daftar, label)
+	}
+
+	sort.Slice(daftar, func(i, j int) bool {
+		if daftar[i].Kategori != daftar[j].Kategori {
+			return daftar[i].Kategori < daftar[j].Kategori
+		}
+		return daftar[i].Kode < daftar[j].Kode
+	})
+
+	return daftar
+}
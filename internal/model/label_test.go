@@ -0,0 +1,39 @@
+package model
+
+import "testing"
+
+func TestCariLabel(t *testing.T) {
+	kasus := map[string]KategoriLabel{
+		"ark":  KategoriRagam,
+		"Ark":  KategoriBidang,
+		"Tan":  KategoriBidang,
+		"Ikn":  KategoriBidang,
+		"Kap":  KategoriBidang,
+		"Ars":  KategoriBidang,
+		"Sen":  KategoriBidang,
+		"Elek": KategoriBidang,
+		"Kom":  KategoriBidang,
+		"Tern": KategoriBidang,
+		"Dag":  KategoriBidang,
+		"Cn":   KategoriBahasaAsing,
+		"Prs":  KategoriBahasaAsing,
+		"Hin":  KategoriBahasaAsing,
+		"Tm":   KategoriBahasaAsing,
+		"Jw":   KategoriBahasaDaerah,
+	}
+
+	for kode, kategori := range kasus {
+		label, ada := CariLabel(kode)
+		if !ada {
+			t.Errorf("label %q tidak dikenal", kode)
+			continue
+		}
+		if label.Kategori != kategori || label.Nama == "" {
+			t.Errorf("label %q = %+v, ingin kategori %s", kode, label, kategori)
+		}
+	}
+
+	if _, ada := CariLabel("xyz"); ada {
+		t.Error("label \"xyz\" seharusnya tidak dikenal")
+	}
+}
@@ -0,0 +1,32 @@
+package model
+
+import "strings"
+
+// JenisE merepresentasikan bunyi huruf e pada pelafalan
+type JenisE string
+
+const (
+	// EPepet adalah e pepet, ditulis "e" (seperti pada "lebar")
+	EPepet JenisE = "pepet"
+
+	// ETaling adalah e taling, ditulis "é" (seperti pada "sate")
+	ETaling JenisE = "taling"
+
+	// ETerbuka adalah e terbuka, ditulis "è" (seperti pada "pèlog")
+	ETerbuka JenisE = "terbuka"
+)
+
+// Lafal merepresentasikan petunjuk pelafalan entri
+type Lafal struct {
+	// Teks adalah pelafalan tanpa garis miring pembatas
+	Teks     string   `json:"teks"`
+	SukuKata []string `json:"suku_kata"`
+
+	// BunyiE berisi jenis setiap huruf e pada Teks sesuai urutannya
+	BunyiE []JenisE `json:"bunyi_e,omitempty"`
+}
+
+// String mengembalikan pelafalan dengan pemisah suku kata
+func (l Lafal) String() string {
+	return strings.Join(l.SukuKata, "·")
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lampiran merepresentasikan bagian lampiran yang menyertai entri, misalnya
+// tabel atau daftar istilah
+type Lampiran struct {
+	Judul string `json:"judul"`
+
+	// Kolom berisi judul kolom tabel, Baris berisi isi setiap baris tabel
+	Kolom []string   `json:"kolom,omitempty"`
+	Baris [][]string `json:"baris,omitempty"`
+
+	// Butir berisi isi lampiran yang bukan tabel, seperti butir daftar
+	// atau paragraf
+	Butir []string `json:"butir,omitempty"`
+}
+
+// String mengembalikan representasi string dari Lampiran
+func (l *Lampiran) String() string {
+	hasil := []string{fmt.Sprintf("Lampiran: %s", l.Judul)}
+
+	if len(l.Kolom) > 0 {
+		hasil = append(hasil, strings.Join(l.Kolom, " | "))
+	}
+	for _, baris := range l.Baris {
+		hasil = append(hasil, strings.Join(baris, " | "))
+	}
+	for _, butir := range l.Butir {
+		hasil = append(hasil, fmt.Sprintf("- %s", butir))
+	}
+
+	return strings.Join(hasil, "\n")
+}
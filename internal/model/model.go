@@ -9,80 +9,144 @@ import (
 
 // Definisi merepresentasikan hasil pencarian dalam KBBI
 type Definisi struct {
-	Pranala    string   `json:"pranala"`
-	Entri      []Entri  `json:"entri"`
+	Pranala string  `json:"pranala"`
+	Entri   []Entri `json:"entri"`
+
+	// Peribahasa dan Idiom berisi teks ungkapan dari semua entri.
+	//
+	// Deprecated: gunakan Entri.Peribahasa dan Entri.Idiom yang menyimpan
+	// makna dan entri asal setiap ungkapan.
 	Peribahasa []string `json:"peribahasa,omitempty"`
 	Idiom      []string `json:"idiom,omitempty"`
-	SaranEntri []string `json:"saran_entri,omitempty"`
+
+	SaranEntri []string   `json:"saran_entri,omitempty"`
+	Lampiran   []Lampiran `json:"lampiran,omitempty"`
 }
 
 // Entri merepresentasikan satu entri dalam KBBI
 type Entri struct {
-	Nama             string      `json:"nama"`
-	Nomor            string      `json:"nomor"`
-	KataDasar        []string    `json:"kata_dasar"`
-	Varian           []string    `json:"varian"`
-	BentukTidakBaku  []string    `json:"bentuk_tidak_baku,omitempty"`
-	Pelafalan        string      `json:"pelafalan"`
-	Makna            []Makna     `json:"makna"`
-	Etimologi        *Etimologi  `json:"etimologi,omitempty"`
-	KataTurunan      []string    `json:"kata_turunan,omitempty"`
-	GabunganKata     []string    `json:"gabungan_kata,omitempty"`
+	// ID adalah pengenal tetap entri dari lema dan nomor homonim, misalnya
+	// "rumah" atau "apel#2"
+	ID string `json:"id"`
+
+	// URL adalah alamat halaman entri di KBBI Daring
+	URL             string   `json:"url"`
+	Nama            string   `json:"nama"`
+	Nomor           string   `json:"nomor"`
+	Lema            string   `json:"lema"`
+	SukuKata        []string `json:"suku_kata"`
+	JumlahSukuKata  int      `json:"jumlah_suku_kata"`
+	KataDasar       []string `json:"kata_dasar"`
+	Varian          []string `json:"varian"`
+	BentukTidakBaku []string `json:"bentuk_tidak_baku,omitempty"`
+
+	// KataDasarRujukan, VarianRujukan, dan BentukTidakBakuRujukan berisi
+	// rujukan lengkap dengan nomor homonim dan URL; field teks di atas
+	// berisi rujukan yang sama dalam bentuk "kata (n)"
+	KataDasarRujukan       []Rujukan `json:"kata_dasar_rujukan,omitempty"`
+	VarianRujukan          []Rujukan `json:"varian_rujukan,omitempty"`
+	BentukTidakBakuRujukan []Rujukan `json:"bentuk_tidak_baku_rujukan,omitempty"`
+
+	Pelafalan string     `json:"pelafalan"`
+	Lafal     *Lafal     `json:"lafal,omitempty"`
+	Makna     []Makna    `json:"makna"`
+	Etimologi *Etimologi `json:"etimologi,omitempty"`
+
+	// KataTurunan dan GabunganKata berisi kata dari bagian Terkait yang
+	// sesuai; nomor homonim dan URL-nya tersedia pada Terkait
+	KataTurunan  []string   `json:"kata_turunan,omitempty"`
+	GabunganKata []string   `json:"gabungan_kata,omitempty"`
+	Peribahasa   []Ungkapan `json:"peribahasa,omitempty"`
+	Idiom        []Ungkapan `json:"idiom,omitempty"`
+
+	// Terkait berisi semua bagian kata terkait berjudul h4 sesuai urutan
+	// di halaman, termasuk yang juga disimpan pada field di atas
+	Terkait []BagianTerkait `json:"terkait,omitempty"`
 }
 
 // Makna merepresentasikan makna dari sebuah entri
 type Makna struct {
+	// Nomor adalah penomoran asli makna di KBBI ("1", "2", atau "a", "b"
+	// untuk makna anak), kosong jika tidak bernomor
+	Nomor    string      `json:"nomor,omitempty"`
 	Kelas    []KelasKata `json:"kelas"`
 	Submakna []string    `json:"submakna"`
-	Info     string      `json:"info"`
-	Contoh   []string    `json:"contoh"`
+
+	// Rujukan berisi rujukan makna beserta nomor homonim dan URL-nya;
+	// teksnya ("→ kata (n)") juga tercantum pada Submakna
+	Rujukan []Rujukan `json:"rujukan,omitempty"`
+
+	Label  []Label  `json:"label"`
+	Info   string   `json:"info"`
+	Contoh []string `json:"contoh"`
+
+	// ContohRinci berisi contoh yang sama dengan Contoh beserta teks
+	// lengkap dan penekanannya
+	ContohRinci []Contoh `json:"contoh_rinci,omitempty"`
+	Anak        []Makna  `json:"anak,omitempty"`
 }
 
 // KelasKata merepresentasikan kelas kata (noun, verb, dll)
 type KelasKata struct {
-	Kode      string `json:"kode"`
-	Nama      string `json:"nama"`
-	Deskripsi string `json:"deskripsi"`
+	Jenis     JenisKelas `json:"jenis"`
+	Kode      string     `json:"kode"`
+	Nama      string     `json:"nama"`
+	Deskripsi string     `json:"deskripsi"`
 }
 
 // Etimologi merepresentasikan asal usul kata
+//
+// Kelas, Bahasa, AsalKata, Pelafalan, dan Arti mengikuti tahap pertama;
+// Tahap berisi seluruh rantai asal kata sesuai urutan di KBBI (misalnya
+// Arab, lalu Persia, lalu Melayu).
 type Etimologi struct {
-	Kelas     []string `json:"kelas"`
+	Kelas     []string         `json:"kelas"`
+	Bahasa    string           `json:"bahasa"`
+	AsalKata  string           `json:"asal_kata"`
+	Pelafalan string           `json:"pelafalan"`
+	Arti      []string         `json:"arti"`
+	Tahap     []TahapEtimologi `json:"tahap"`
+}
+
+// TahapEtimologi merepresentasikan satu tahap dalam rantai asal kata
+type TahapEtimologi struct {
 	Bahasa    string   `json:"bahasa"`
+	Kelas     []string `json:"kelas"`
 	AsalKata  string   `json:"asal_kata"`
 	Pelafalan string   `json:"pelafalan"`
 	Arti      []string `json:"arti"`
 }
 
+// IDEntri menyusun ID entri dari lema dan nomor homonimnya
+func IDEntri(lema, nomor string) string {
+	if nomor == "" {
+		return lema
+	}
+	return fmt.Sprintf("%s#%s", lema, nomor)
+}
+
 // String mengembalikan representasi string dari Definisi
 func (d *Definisi) String() string {
 	if len(d.SaranEntri) > 0 && len(d.Entri) == 0 {
-		return fmt.Sprintf("Berikut beberapa saran entri lain yang mirip.\n%s", 
+		return fmt.Sprintf("Berikut beberapa saran entri lain yang mirip.\n%s",
 			strings.Join(d.SaranEntri, ", "))
 	}
-	
+
 	var hasil []string
 	for _, entri := range d.Entri {
 		hasil = append(hasil, entri.String())
 	}
-	
-	// Tambahkan Peribahasa dan Idiom jika ada
-	if len(d.Peribahasa) > 0 {
-		hasil = append(hasil, fmt.Sprintf("\nPeribahasa\n%s", 
-			strings.Join(d.Peribahasa, "; ")))
+	for _, lampiran := range d.Lampiran {
+		hasil = append(hasil, lampiran.String())
 	}
-	if len(d.Idiom) > 0 {
-		hasil = append(hasil, fmt.Sprintf("\nIdiom\n%s", 
-			strings.Join(d.Idiom, "; ")))
-	}
-	
+
 	return strings.Join(hasil, "\n\n")
 }
 
 // String mengembalikan representasi string dari Entri
 func (e *Entri) String() string {
 	var hasil []string
-	
+
 	// Nama entri dengan kata dasar jika ada
 	nama := e.Nama
 	if e.Nomor != "" {
@@ -91,90 +155,175 @@ func (e *Entri) String() string {
 	if len(e.KataDasar) > 0 {
 		nama = fmt.Sprintf("%s » %s", strings.Join(e.KataDasar, " » "), nama)
 	}
-	
+
 	// Tambahkan pelafalan jika ada
 	if e.Pelafalan != "" {
 		nama += fmt.Sprintf("  %s", e.Pelafalan)
 	}
 	hasil = append(hasil, nama)
-	
+
 	// Varian atau bentuk tidak baku
 	if len(e.BentukTidakBaku) > 0 {
-		hasil = append(hasil, fmt.Sprintf("bentuk tidak baku: %s", 
+		hasil = append(hasil, fmt.Sprintf("bentuk tidak baku: %s",
 			strings.Join(e.BentukTidakBaku, ", ")))
 	} else if len(e.Varian) > 0 {
-		hasil = append(hasil, fmt.Sprintf("varian: %s", 
+		hasil = append(hasil, fmt.Sprintf("varian: %s",
 			strings.Join(e.Varian, ", ")))
 	}
-	
+
 	// Etimologi
 	if e.Etimologi != nil {
 		hasil = append(hasil, fmt.Sprintf("Etimologi: %s", e.Etimologi.String()))
 	}
-	
-	// Makna
+
+	// Makna beserta makna anaknya
 	if len(e.Makna) > 0 {
 		if len(e.Makna) > 1 {
-			for i, makna := range e.Makna {
-				hasil = append(hasil, fmt.Sprintf("%d. %s", i+1, makna.String()))
+			for i := range e.Makna {
+				hasil = append(hasil, barisMakna(&e.Makna[i], nomorTampil(e.Makna[i], i), "")...)
 			}
 		} else {
-			hasil = append(hasil, e.Makna[0].String())
+			hasil = append(hasil, barisMakna(&e.Makna[0], "", "")...)
 		}
 	}
-	
+
 	// Kata terkait
 	if len(e.KataTurunan) > 0 {
-		hasil = append(hasil, fmt.Sprintf("\nKata Turunan\n%s", 
+		hasil = append(hasil, fmt.Sprintf("\nKata Turunan\n%s",
 			strings.Join(e.KataTurunan, "; ")))
 	}
 	if len(e.GabunganKata) > 0 {
-		hasil = append(hasil, fmt.Sprintf("\nGabungan Kata\n%s", 
+		hasil = append(hasil, fmt.Sprintf("\nGabungan Kata\n%s",
 			strings.Join(e.GabunganKata, "; ")))
 	}
-	
+	if len(e.Peribahasa) > 0 {
+		hasil = append(hasil, fmt.Sprintf("\nPeribahasa\n%s",
+			gabungUngkapan(e.Peribahasa, "; ")))
+	}
+	if len(e.Idiom) > 0 {
+		hasil = append(hasil, fmt.Sprintf("\nIdiom\n%s",
+			gabungUngkapan(e.Idiom, "; ")))
+	}
+	for _, bagian := range e.Terkait {
+		if !bagian.Bertipe() && len(bagian.Kata) > 0 {
+			hasil = append(hasil, "\n"+bagian.String())
+		}
+	}
+
 	return strings.Join(hasil, "\n")
 }
 
+// barisMakna mengembalikan baris teks makna beserta makna anaknya, masing-
+// masing menjorok sesuai tingkatnya
+func barisMakna(m *Makna, nomor, indentasi string) []string {
+	teks := m.String()
+	if nomor != "" {
+		teks = fmt.Sprintf("%s. %s", nomor, teks)
+	}
+
+	hasil := []string{indentasi + teks}
+	for i := range m.Anak {
+		hasil = append(hasil, barisMakna(&m.Anak[i], nomorTampil(m.Anak[i], i), indentasi+"   ")...)
+	}
+	return hasil
+}
+
+// nomorTampil mengembalikan nomor asli makna, atau urutannya jika makna
+// tidak bernomor
+func nomorTampil(m Makna, i int) string {
+	if m.Nomor != "" {
+		return m.Nomor
+	}
+	return fmt.Sprintf("%d", i+1)
+}
+
 // String mengembalikan representasi string dari Makna
 func (m *Makna) String() string {
 	var hasil []string
-	
-	// Kelas kata
-	if len(m.Kelas) > 0 {
-		var kelas []string
-		for _, k := range m.Kelas {
-			kelas = append(kelas, fmt.Sprintf("(%s)", k.Kode))
+
+	// Kelas kata, beserta label yang tidak tertulis pada info
+	var kelas []string
+	for _, k := range m.Kelas {
+		kelas = append(kelas, fmt.Sprintf("(%s)", k.Kode))
+	}
+	for _, l := range m.Label {
+		if !strings.Contains(" "+m.Info+" ", " "+l.Kode+" ") {
+			kelas = append(kelas, fmt.Sprintf("(%s)", l.Kode))
 		}
+	}
+	if len(kelas) > 0 {
 		hasil = append(hasil, strings.Join(kelas, " "))
 	}
-	
-	// Submakna
-	hasil = append(hasil, strings.Join(m.Submakna, "; "))
-	
+
+	// Submakna, termasuk rujukan dalam bentuk "→ kata (n)"
+	if len(m.Submakna) > 0 {
+		hasil = append(hasil, strings.Join(m.Submakna, "; "))
+	}
+
 	// Info tambahan
 	if m.Info != "" {
 		hasil = append(hasil, m.Info)
 	}
-	
+
 	// Contoh
 	if len(m.Contoh) > 0 {
-		return fmt.Sprintf("%s: %s", strings.Join(hasil, "  "), 
+		return fmt.Sprintf("%s: %s", strings.Join(hasil, "  "),
 			strings.Join(m.Contoh, "; "))
 	}
-	
+
 	return strings.Join(hasil, "  ")
 }
 
-// String mengembalikan representasi string dari Etimologi
+// Isi mengembalikan isi makna tanpa kelas kata, label, info, dan contoh:
+// submakna, rujukan yang belum tercantum pada submakna, lalu isi makna
+// anak, dipisahkan "; "
+func (m *Makna) Isi() string {
+	bagian := append([]string(nil), m.Submakna...)
+	for _, r := range m.Rujukan {
+		teks := r.String()
+		tercantum := false
+		for _, sub := range m.Submakna {
+			if sub == teks {
+				tercantum = true
+				break
+			}
+		}
+		if !tercantum {
+			bagian = append(bagian, teks)
+		}
+	}
+	for i := range m.Anak {
+		if isi := m.Anak[i].Isi(); isi != "" {
+			bagian = append(bagian, isi)
+		}
+	}
+	return strings.Join(bagian, "; ")
+}
+
+// String mengembalikan representasi string dari Etimologi, dengan tahap
+// dipisahkan "→"
 func (e *Etimologi) String() string {
+	if len(e.Tahap) == 0 {
+		t := TahapEtimologi{Bahasa: e.Bahasa, Kelas: e.Kelas, AsalKata: e.AsalKata, Pelafalan: e.Pelafalan, Arti: e.Arti}
+		return t.String()
+	}
+
+	tahap := make([]string, len(e.Tahap))
+	for i := range e.Tahap {
+		tahap[i] = e.Tahap[i].String()
+	}
+	return strings.Join(tahap, " → ")
+}
+
+// String mengembalikan representasi string dari satu tahap etimologi
+func (e *TahapEtimologi) String() string {
 	var hasil []string
-	
+
 	// Bahasa asal
 	if e.Bahasa != "" {
 		hasil = append(hasil, fmt.Sprintf("[%s]", e.Bahasa))
 	}
-	
+
 	// Kelas kata
 	if len(e.Kelas) > 0 {
 		var kelas []string
@@ -183,20 +332,20 @@ func (e *Etimologi) String() string {
 		}
 		hasil = append(hasil, strings.Join(kelas, " "))
 	}
-	
+
 	// Kata asal dan pelafalan
 	asalKata := e.AsalKata
 	if e.Pelafalan != "" {
 		asalKata += fmt.Sprintf(" %s", e.Pelafalan)
 	}
 	hasil = append(hasil, asalKata)
-	
+
 	// Arti
 	if len(e.Arti) > 0 {
-		return fmt.Sprintf("%s: %s", strings.Join(hasil, " "), 
+		return fmt.Sprintf("%s: %s", strings.Join(hasil, " "),
 			strings.Join(e.Arti, "; "))
 	}
-	
+
 	return strings.Join(hasil, " ")
 }
 
@@ -204,16 +353,16 @@ func (e *Etimologi) String() string {
 func (d *Definisi) ToJSON(indent bool) (string, error) {
 	var data []byte
 	var err error
-	
+
 	if indent {
 		data, err = json.MarshalIndent(d, "", "  ")
 	} else {
 		data, err = json.Marshal(d)
 	}
-	
+
 	if err != nil {
 		return "", fmt.Errorf("gagal mengkonversi ke JSON: %w", err)
 	}
-	
+
 	return string(data), nil
-}
\ No newline at end of file
+}
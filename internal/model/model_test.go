@@ -0,0 +1,36 @@
+package model
+
+import "testing"
+
+func TestMaknaIsi(t *testing.T) {
+	kerja := Rujukan{Kata: "kerja", Nomor: "1", Jenis: RujukanMakna}
+	kasus := map[string]struct {
+		makna Makna
+		ingin string
+	}{
+		"submakna": {
+			makna: Makna{Kelas: []KelasKata{{Kode: "n"}}, Submakna: []string{"keluarga"}, Contoh: []string{"-- bahagia"}},
+			ingin: "keluarga",
+		},
+		"rujukan pada submakna": {
+			makna: Makna{Submakna: []string{kerja.String()}, Rujukan: []Rujukan{kerja}},
+			ingin: "→ kerja (1)",
+		},
+		"rujukan tanpa submakna": {
+			makna: Makna{Rujukan: []Rujukan{kerja}},
+			ingin: "→ kerja (1)",
+		},
+		"makna anak": {
+			makna: Makna{Submakna: []string{"gedung"}, Anak: []Makna{{Submakna: []string{"umum"}}, {Rujukan: []Rujukan{kerja}}}},
+			ingin: "gedung; umum; → kerja (1)",
+		},
+	}
+
+	for nama, k := range kasus {
+		t.Run(nama, func(t *testing.T) {
+			if isi := k.makna.Isi(); isi != k.ingin {
+				t.Errorf("Isi() = %q, ingin %q", isi, k.ingin)
+			}
+		})
+	}
+}
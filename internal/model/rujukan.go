@@ -0,0 +1,115 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JenisRujukan merepresentasikan hubungan antara entri dan kata yang dirujuk
+type JenisRujukan int
+
+const (
+	// RujukanMakna adalah rujukan pada makna ("→ kata")
+	RujukanMakna JenisRujukan = iota
+
+	// RujukanKataDasar adalah kata dasar dari entri turunan
+	RujukanKataDasar
+
+	// RujukanVarian adalah varian dari entri
+	RujukanVarian
+
+	// RujukanBentukTidakBaku adalah bentuk tidak baku dari entri
+	RujukanBentukTidakBaku
+
+	// RujukanTerkait adalah kata pada bagian kata terkait (h4) entri
+	RujukanTerkait
+)
+
+// String mengembalikan nama jenis rujukan
+func (j JenisRujukan) String() string {
+	switch j {
+	case RujukanKataDasar:
+		return "kata_dasar"
+	case RujukanVarian:
+		return "varian"
+	case RujukanBentukTidakBaku:
+		return "bentuk_tidak_baku"
+	case RujukanTerkait:
+		return "terkait"
+	default:
+		return "makna"
+	}
+}
+
+// MarshalText mengenkode jenis rujukan sebagai namanya
+func (j JenisRujukan) MarshalText() ([]byte, error) {
+	return []byte(j.String()), nil
+}
+
+// UnmarshalText membaca jenis rujukan dari namanya
+func (j *JenisRujukan) UnmarshalText(teks []byte) error {
+	switch string(teks) {
+	case "makna":
+		*j = RujukanMakna
+	case "kata_dasar":
+		*j = RujukanKataDasar
+	case "varian":
+		*j = RujukanVarian
+	case "bentuk_tidak_baku":
+		*j = RujukanBentukTidakBaku
+	case "terkait":
+		*j = RujukanTerkait
+	default:
+		return fmt.Errorf("jenis rujukan tidak dikenal: %q", teks)
+	}
+	return nil
+}
+
+// Rujukan merepresentasikan rujukan ke entri lain
+type Rujukan struct {
+	Kata  string       `json:"kata"`
+	Nomor string       `json:"nomor,omitempty"`
+	Jenis JenisRujukan `json:"jenis"`
+	URL   string       `json:"url,omitempty"`
+}
+
+// String mengembalikan rujukan dalam bentuk "kata (n)", diawali "→ " untuk
+// rujukan makna
+func (r Rujukan) String() string {
+	teks := r.Kata
+	if r.Nomor != "" {
+		teks = fmt.Sprintf("%s (%s)", teks, r.Nomor)
+	}
+	if r.Jenis == RujukanMakna {
+		teks = fmt.Sprintf("→ %s", teks)
+	}
+	return teks
+}
+
+// gabungRujukan menggabungkan rujukan dalam bentuk teks dengan pemisah
+func gabungRujukan(rujukan []Rujukan, pemisah string) string {
+	teks := make([]string, len(rujukan))
+	for i, r := range rujukan {
+		teks[i] = r.String()
+	}
+	return strings.Join(teks, pemisah)
+}
+
+// KataRujukan mengambil kata dari setiap rujukan, tanpa nomor homonim
+// maupun URL
+func KataRujukan(rujukan []Rujukan) []string {
+	kata := make([]string, len(rujukan))
+	for i, r := range rujukan {
+		kata[i] = r.Kata
+	}
+	return kata
+}
+
+// TeksRujukan mengubah setiap rujukan menjadi teks "kata (n)"
+func TeksRujukan(rujukan []Rujukan) []string {
+	teks := make([]string, len(rujukan))
+	for i, r := range rujukan {
+		teks[i] = r.String()
+	}
+	return teks
+}
@@ -0,0 +1,30 @@
+package model
+
+import "strings"
+
+// bagianBertipe berisi judul bagian kata terkait yang juga disimpan pada
+// field tersendiri di Entri
+var bagianBertipe = []string{"Kata Turunan", "Gabungan Kata", "Peribahasa", "Idiom"}
+
+// BagianTerkait merepresentasikan satu bagian kata terkait berjudul h4 pada
+// halaman entri, misalnya "Kata Turunan" atau "Lihat juga"
+type BagianTerkait struct {
+	Judul string    `json:"judul"`
+	Kata  []Rujukan `json:"kata"`
+}
+
+// Bertipe memeriksa apakah bagian juga disimpan pada field tersendiri
+// (KataTurunan, GabunganKata, Peribahasa, atau Idiom)
+func (b BagianTerkait) Bertipe() bool {
+	for _, judul := range bagianBertipe {
+		if strings.Contains(b.Judul, judul) {
+			return true
+		}
+	}
+	return false
+}
+
+// String mengembalikan judul bagian diikuti kata-katanya
+func (b BagianTerkait) String() string {
+	return b.Judul + "\n" + gabungRujukan(b.Kata, "; ")
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JenisUngkapan merepresentasikan jenis ungkapan pada entri
+type JenisUngkapan int
+
+const (
+	// UngkapanPeribahasa adalah peribahasa yang mengandung entri
+	UngkapanPeribahasa JenisUngkapan = iota
+
+	// UngkapanIdiom adalah idiom yang mengandung entri
+	UngkapanIdiom
+)
+
+// String mengembalikan nama jenis ungkapan
+func (j JenisUngkapan) String() string {
+	if j == UngkapanIdiom {
+		return "idiom"
+	}
+	return "peribahasa"
+}
+
+// MarshalText mengenkode jenis ungkapan sebagai namanya
+func (j JenisUngkapan) MarshalText() ([]byte, error) {
+	return []byte(j.String()), nil
+}
+
+// UnmarshalText membaca jenis ungkapan dari namanya
+func (j *JenisUngkapan) UnmarshalText(teks []byte) error {
+	switch string(teks) {
+	case "peribahasa":
+		*j = UngkapanPeribahasa
+	case "idiom":
+		*j = UngkapanIdiom
+	default:
+		return fmt.Errorf("jenis ungkapan tidak dikenal: %q", teks)
+	}
+	return nil
+}
+
+// Ungkapan merepresentasikan peribahasa atau idiom beserta maknanya
+type Ungkapan struct {
+	Teks  string        `json:"teks"`
+	Makna string        `json:"makna,omitempty"`
+	Jenis JenisUngkapan `json:"jenis"`
+	URL   string        `json:"url,omitempty"`
+
+	// Entri dan NomorEntri menunjukkan entri (beserta nomor homonimnya)
+	// tempat ungkapan ini tercantum
+	Entri      string `json:"entri"`
+	NomorEntri string `json:"nomor_entri,omitempty"`
+}
+
+// String mengembalikan ungkapan dalam bentuk "teks: makna"
+func (u Ungkapan) String() string {
+	if u.Makna == "" {
+		return u.Teks
+	}
+	return fmt.Sprintf("%s: %s", u.Teks, u.Makna)
+}
+
+// gabungUngkapan menggabungkan ungkapan dalam bentuk teks dengan pemisah
+func gabungUngkapan(ungkapan []Ungkapan, pemisah string) string {
+	teks := make([]string, len(ungkapan))
+	for i, u := range ungkapan {
+		teks[i] = u.String()
+	}
+	return strings.Join(teks, pemisah)
+}
@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/model"
+)
+
+// selektorContoh adalah elemen pembungkus contoh pemakaian pada makna
+const selektorContoh = `font[color="grey"], font[color="gray"]`
+
+// potongan adalah sepotong teks contoh beserta gaya penekanannya
+type potongan struct {
+	teks string
+	gaya model.GayaPenekanan
+}
+
+// parseContoh mengurai contoh pemakaian dari elemen contoh di dalam makna
+//
+// Satu elemen contoh bisa berisi beberapa contoh yang dipisahkan titik koma.
+func parseContoh(s *goquery.Selection, lema string) []model.Contoh {
+	contoh := []model.Contoh{}
+
+	s.Find(selektorContoh).Each(func(i int, el *goquery.Selection) {
+		contoh = append(contoh, pisahkanContoh(kumpulkanPotongan(el), lema)...)
+	})
+
+	return contoh
+}
+
+// kumpulkanPotongan mengumpulkan teks elemen contoh beserta gayanya
+func kumpulkanPotongan(el *goquery.Selection) []potongan {
+	// Cetak miring yang membungkus seluruh contoh adalah gaya bawaan contoh,
+	// bukan penekanan
+	akar := el
+	anak := el.Children()
+	if anak.Length() == 1 && gayaElemen(goquery.NodeName(anak)) == model.GayaMiring &&
+		strings.TrimSpace(anak.Text()) == strings.TrimSpace(el.Text()) {
+		akar = anak
+	}
+
+	var hasil []potongan
+	var jelajahi func(s *goquery.Selection, gaya model.GayaPenekanan)
+	jelajahi = func(s *goquery.Selection, gaya model.GayaPenekanan) {
+		s.Contents().Each(func(i int, node *goquery.Selection) {
+			nama := goquery.NodeName(node)
+			if nama == "#text" {
+				hasil = append(hasil, potongan{teks: node.Text(), gaya: gaya})
+				return
+			}
+
+			gayaAnak := gaya
+			if g := gayaElemen(nama); g != "" {
+				gayaAnak = g
+			}
+			jelajahi(node, gayaAnak)
+		})
+	}
+	jelajahi(akar, "")
+
+	return hasil
+}
+
+// gayaElemen mengembalikan gaya penekanan untuk nama elemen
+func gayaElemen(nama string) model.GayaPenekanan {
+	switch nama {
+	case "i", "em":
+		return model.GayaMiring
+	case "b", "strong":
+		return model.GayaTebal
+	default:
+		return ""
+	}
+}
+
+// pisahkanContoh memisahkan potongan teks menjadi contoh-contoh pada titik
+// koma, dengan penekanan tetap mengikuti posisinya
+func pisahkanContoh(daftar []potongan, lema string) []model.Contoh {
+	var hasil []model.Contoh
+	var saatIni []potongan
+
+	for _, p := range daftar {
+		bagian := strings.Split(p.teks, ";")
+		for i, teks := range bagian {
+			if i > 0 {
+				if c, ok := buatContoh(saatIni, lema); ok {
+					hasil = append(hasil, c)
+				}
+				saatIni = nil
+			}
+			saatIni = append(saatIni, potongan{teks: teks, gaya: p.gaya})
+		}
+	}
+	if c, ok := buatContoh(saatIni, lema); ok {
+		hasil = append(hasil, c)
+	}
+
+	return hasil
+}
+
+// buatContoh menyusun satu contoh dari potongan teksnya
+func buatContoh(daftar []potongan, lema string) (model.Contoh, bool) {
+	var sb strings.Builder
+	var penekanan []model.Penekanan
+
+	for _, p := range daftar {
+		awal := sb.Len()
+		sb.WriteString(p.teks)
+		if p.gaya != "" {
+			penekanan = append(penekanan, model.Penekanan{Awal: awal, Akhir: sb.Len(), Gaya: p.gaya})
+		}
+	}
+
+	mentah := sb.String()
+	teks := strings.TrimSpace(mentah)
+	if teks == "" {
+		return model.Contoh{}, false
+	}
+	geser := len(mentah) - len(strings.TrimLeftFunc(mentah, unicode.IsSpace))
+
+	contoh := model.Contoh{
+		Teks:        teks,
+		TeksLengkap: gantiLema(teks, lema),
+		Penekanan:   []model.Penekanan{},
+	}
+
+	for _, p := range penekanan {
+		p.Awal = batasi(p.Awal-geser, len(teks))
+		p.Akhir = batasi(p.Akhir-geser, len(teks))
+		potong := teks[p.Awal:p.Akhir]
+		p.Awal += len(potong) - len(strings.TrimLeftFunc(potong, unicode.IsSpace))
+		p.Akhir -= len(potong) - len(strings.TrimRightFunc(potong, unicode.IsSpace))
+		if p.Awal >= p.Akhir {
+			continue
+		}
+		p.Teks = teks[p.Awal:p.Akhir]
+		contoh.Penekanan = append(contoh.Penekanan, p)
+	}
+
+	return contoh, true
+}
+
+// gantiLema mengganti pengganti entri ("--" dan "~") dengan entrinya
+func gantiLema(teks, lema string) string {
+	if lema == "" {
+		return teks
+	}
+	teks = strings.ReplaceAll(teks, "--", lema)
+	return strings.ReplaceAll(teks, "~", lema)
+}
+
+// batasi membatasi nilai ke rentang 0..maks
+func batasi(nilai, maks int) int {
+	if nilai < 0 {
+		return 0
+	}
+	if nilai > maks {
+		return maks
+	}
+	return nilai
+}
@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// panjangCuplikan adalah batas panjang cuplikan HTML pada diagnostik
+const panjangCuplikan = 160
+
+// ErrTataLetak dikembalikan dalam mode ketat ketika struktur halaman tidak
+// sesuai dengan yang dikenali parser, biasanya karena KBBI mengubah markup
+var ErrTataLetak = errors.New("tata letak halaman KBBI tidak dikenali")
+
+// TingkatDiagnostik menyatakan seberapa serius sebuah diagnostik
+type TingkatDiagnostik int
+
+const (
+	// TingkatInfo untuk hal yang wajar ditemui pada halaman KBBI, misalnya
+	// label atau kelas kata yang belum ada dalam daftar
+	TingkatInfo TingkatDiagnostik = iota
+
+	// TingkatPeringatan untuk bagian entri yang dilewati atau tidak dapat
+	// diurai, sementara bagian lain tetap utuh
+	TingkatPeringatan
+
+	// TingkatStruktur untuk kerusakan tata letak halaman yang membuat entri
+	// hilang; hanya tingkat ini yang menjadi error dalam mode ketat
+	TingkatStruktur
+)
+
+// String mengembalikan nama tingkat diagnostik
+func (t TingkatDiagnostik) String() string {
+	switch t {
+	case TingkatPeringatan:
+		return "peringatan"
+	case TingkatStruktur:
+		return "struktur"
+	default:
+		return "info"
+	}
+}
+
+// MarshalText mengenkode tingkat diagnostik sebagai namanya
+func (t TingkatDiagnostik) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText membaca tingkat diagnostik dari namanya
+func (t *TingkatDiagnostik) UnmarshalText(teks []byte) error {
+	for tingkat := TingkatInfo; tingkat <= TingkatStruktur; tingkat++ {
+		if tingkat.String() == string(teks) {
+			*t = tingkat
+			return nil
+		}
+	}
+	return fmt.Errorf("tingkat diagnostik tidak dikenal: %q", teks)
+}
+
+// Diagnostik adalah catatan tentang bagian halaman yang tidak dapat
+// diurai sebagaimana mestinya
+type Diagnostik struct {
+	// Tingkat menyatakan keseriusan masalah
+	Tingkat TingkatDiagnostik `json:"tingkat"`
+
+	// Entri adalah nama entri tempat masalah ditemukan, kosong untuk
+	// masalah di level halaman
+	Entri string `json:"entri,omitempty"`
+
+	// Konteks adalah selektor atau bagian halaman yang sedang diurai
+	Konteks string `json:"konteks"`
+
+	// Pesan menjelaskan masalah yang ditemukan
+	Pesan string `json:"pesan"`
+
+	// Cuplikan adalah potongan HTML dari elemen yang bermasalah
+	Cuplikan string `json:"cuplikan,omitempty"`
+}
+
+// String mengembalikan diagnostik dalam satu baris
+func (d Diagnostik) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s: ", d.Tingkat))
+	if d.Entri != "" {
+		sb.WriteString(fmt.Sprintf("[%s] ", d.Entri))
+	}
+	sb.WriteString(fmt.Sprintf("%s: %s", d.Konteks, d.Pesan))
+	if d.Cuplikan != "" {
+		sb.WriteString(fmt.Sprintf(" (%s)", d.Cuplikan))
+	}
+	return sb.String()
+}
+
+// Fatal melaporkan apakah diagnostik menggagalkan parsing dalam mode ketat
+func (d Diagnostik) Fatal() bool {
+	return d.Tingkat >= TingkatStruktur
+}
+
+// KesalahanParsing dikembalikan dalam mode ketat ketika ada diagnostik
+// TingkatStruktur, dan berisi semua diagnostik yang ditemukan
+//
+// errors.Is(err, ErrTataLetak) selalu berlaku; errors.Is(err, ErrTanpaEntri)
+// juga berlaku jika halaman tidak berisi entri maupun saran entri.
+type KesalahanParsing struct {
+	Diagnostik []Diagnostik
+	Err        error
+}
+
+func (e *KesalahanParsing) Error() string {
+	pesan := e.Err.Error()
+
+	var fatal []Diagnostik
+	for _, d := range e.Diagnostik {
+		if d.Fatal() {
+			fatal = append(fatal, d)
+		}
+	}
+	if len(fatal) == 0 {
+		return pesan
+	}
+	pesan = fmt.Sprintf("%s: %s", pesan, fatal[0].String())
+	if len(fatal) > 1 {
+		pesan = fmt.Sprintf("%s (dan %d masalah lain)", pesan, len(fatal)-1)
+	}
+	return pesan
+}
+
+// Unwrap mengembalikan error penyebab
+func (e *KesalahanParsing) Unwrap() error {
+	return e.Err
+}
+
+// Is mencocokkan kesalahan dengan ErrTataLetak
+func (e *KesalahanParsing) Is(target error) bool {
+	return target == ErrTataLetak
+}
+
+// catatan mengumpulkan diagnostik selama parsing
+type catatan struct {
+	entri  string
+	daftar []Diagnostik
+}
+
+// catat menambahkan diagnostik untuk entri yang sedang diurai
+func (c *catatan) catat(tingkat TingkatDiagnostik, konteks, pesan string, s *goquery.Selection) {
+	c.daftar = append(c.daftar, Diagnostik{
+		Tingkat:  tingkat,
+		Entri:    c.entri,
+		Konteks:  konteks,
+		Pesan:    pesan,
+		Cuplikan: cuplikan(s),
+	})
+}
+
+// info mencatat hal wajar yang tidak memengaruhi hasil parsing
+func (c *catatan) info(konteks, pesan string, s *goquery.Selection) {
+	c.catat(TingkatInfo, konteks, pesan, s)
+}
+
+// peringatan mencatat bagian entri yang dilewati atau tidak dapat diurai
+func (c *catatan) peringatan(konteks, pesan string, s *goquery.Selection) {
+	c.catat(TingkatPeringatan, konteks, pesan, s)
+}
+
+// struktur mencatat tata letak halaman yang tidak dikenali
+func (c *catatan) struktur(konteks, pesan string, s *goquery.Selection) {
+	c.catat(TingkatStruktur, konteks, pesan, s)
+}
+
+// fatal melaporkan apakah ada diagnostik TingkatStruktur
+func (c *catatan) fatal() bool {
+	for _, d := range c.daftar {
+		if d.Fatal() {
+			return true
+		}
+	}
+	return false
+}
+
+// cuplikan mengambil potongan HTML elemen dengan spasi yang dirapikan
+func cuplikan(s *goquery.Selection) string {
+	if s == nil || s.Length() == 0 {
+		return ""
+	}
+
+	html, err := goquery.OuterHtml(s.First())
+	if err != nil {
+		return ""
+	}
+
+	html = strings.Join(strings.Fields(html), " ")
+	if utf8.RuneCountInString(html) > panjangCuplikan {
+		html = string([]rune(html)[:panjangCuplikan]) + "…"
+	}
+	return html
+}
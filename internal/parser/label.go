@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/model"
+)
+
+// parseLabel mengurai label pemakaian dari elemen info (color="green")
+//
+// Label biasanya berupa span dengan atribut title berisi nama lengkapnya;
+// tanpa span, setiap kata pada teks info dianggap sebagai kode label.
+func parseLabel(info *goquery.Selection, c *catatan) []model.Label {
+	label := []model.Label{}
+
+	span := info.Find("span")
+	if span.Length() > 0 {
+		span.Each(func(i int, el *goquery.Selection) {
+			if kode := strings.TrimSpace(el.Text()); kode != "" {
+				label = append(label, buatLabel(kode, el.AttrOr("title", ""), el, c))
+			}
+		})
+		return label
+	}
+
+	for _, kode := range strings.Fields(info.Text()) {
+		label = append(label, buatLabel(kode, "", info, c))
+	}
+
+	return label
+}
+
+// buatLabel menyusun label dari kodenya, melengkapinya dari daftar label
+// yang dikenali, dan mencatat diagnostik info untuk kode yang tidak dikenal
+func buatLabel(kode, title string, el *goquery.Selection, c *catatan) model.Label {
+	label, dikenal := model.CariLabel(kode)
+	if !dikenal {
+		label = model.Label{Kode: kode, Kategori: model.KategoriTidakDikenal}
+		c.info(`[color="green"]`, fmt.Sprintf("label %q tidak dikenal", kode), el)
+	}
+
+	// Keterangan dari halaman lebih diutamakan daripada daftar bawaan
+	if nama := strings.TrimSpace(strings.SplitN(title, ": ", 2)[0]); nama != "" {
+		label.Nama = nama
+	}
+
+	return label
+}
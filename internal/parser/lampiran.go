@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/model"
+)
+
+// parseLampiran mengurai satu bagian lampiran dari HTML
+func parseLampiran(htmlLampiran string, c *catatan) model.Lampiran {
+	lampiran := model.Lampiran{}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlLampiran))
+	if err != nil {
+		c.peringatan("lampiran", fmt.Sprintf("gagal parsing HTML lampiran: %v", err), nil)
+		return lampiran
+	}
+
+	judul := doc.Find("h2").First()
+	lampiran.Judul = rapikanTeks(judul.Text())
+	if sisa, ada := strings.CutPrefix(lampiran.Judul, "Lampiran:"); ada {
+		lampiran.Judul = strings.TrimSpace(sisa)
+	}
+
+	// Tabel: baris pertama berisi th menjadi judul kolom
+	doc.Find("tr").Each(func(i int, tr *goquery.Selection) {
+		var sel []string
+		tr.Find("th, td").Each(func(j int, s *goquery.Selection) {
+			sel = append(sel, rapikanTeks(s.Text()))
+		})
+		if len(sel) == 0 {
+			return
+		}
+		if tr.Find("td").Length() == 0 && len(lampiran.Kolom) == 0 && len(lampiran.Baris) == 0 {
+			lampiran.Kolom = sel
+			return
+		}
+		lampiran.Baris = append(lampiran.Baris, sel)
+	})
+
+	// Daftar dan paragraf di luar tabel
+	doc.Find("li, p").Each(func(i int, s *goquery.Selection) {
+		if s.Closest("table").Length() > 0 || s.Find("li, p").Length() > 0 {
+			return
+		}
+		if teks := rapikanTeks(s.Text()); teks != "" {
+			lampiran.Butir = append(lampiran.Butir, teks)
+		}
+	})
+
+	// Teks lepas tanpa tabel maupun daftar
+	if len(lampiran.Baris) == 0 && len(lampiran.Butir) == 0 {
+		doc.Find("body").Children().Not("h2").Each(func(i int, s *goquery.Selection) {
+			if teks := rapikanTeks(s.Text()); teks != "" {
+				lampiran.Butir = append(lampiran.Butir, teks)
+			}
+		})
+	}
+
+	if len(lampiran.Kolom) == 0 && len(lampiran.Baris) == 0 && len(lampiran.Butir) == 0 {
+		c.peringatan("h2", fmt.Sprintf("lampiran %q tidak berisi tabel maupun butir", lampiran.Judul), judul)
+	}
+
+	return lampiran
+}
+
+// rapikanTeks merapikan spasi berlebih pada teks
+func rapikanTeks(teks string) string {
+	return strings.Join(strings.Fields(teks), " ")
+}
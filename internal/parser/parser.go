@@ -2,18 +2,84 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/model"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
 )
 
+// Tampilan menentukan tata letak halaman yang diurai
+type Tampilan int
+
+const (
+	// TampilanOtomatis mendeteksi tata letak dari halaman: halaman tanpa
+	// tautan masuk (#loginLink) dianggap halaman pengguna terdaftar
+	TampilanOtomatis Tampilan = iota
+
+	// TampilanUmum mengurai halaman untuk pengguna umum
+	TampilanUmum
+
+	// TampilanPengguna mengurai halaman untuk pengguna terdaftar, termasuk
+	// etimologi dan kata terkait
+	TampilanPengguna
+)
+
+// Opsi adalah pengaturan parsing
+type Opsi struct {
+	// Tampilan menentukan tata letak halaman, default TampilanOtomatis
+	Tampilan Tampilan
+
+	// Ketat mengubah diagnostik TingkatStruktur menjadi *KesalahanParsing,
+	// termasuk halaman yang tidak berisi entri maupun saran entri; diagnostik
+	// info dan peringatan tetap dikembalikan tanpa error
+	Ketat bool
+
+	// TanpaLampiran melewati bagian lampiran (h2 style="color:gray")
+	// alih-alih mengisinya ke Definisi.Lampiran
+	TanpaLampiran bool
+}
+
+// ErrTanpaEntri dikembalikan dalam mode ketat ketika halaman tidak berisi
+// entri maupun saran entri
+var ErrTanpaEntri = errors.New("halaman tidak berisi entri KBBI")
+
 // ParseDefinisi mengurai HTML menjadi struktur Definisi
 func ParseDefinisi(html string, terautentikasi bool) (*model.Definisi, error) {
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	tampilan := TampilanUmum
+	if terautentikasi {
+		tampilan = TampilanPengguna
+	}
+	definisi, _, err := ParseDefinisiDari(strings.NewReader(html), Opsi{Tampilan: tampilan})
+	return definisi, err
+}
+
+// ParseDefinisiDari mengurai HTML dari reader menjadi struktur Definisi,
+// beserta diagnostik untuk bagian halaman yang tidak dikenali
+func ParseDefinisiDari(r io.Reader, opsi Opsi) (*model.Definisi, []Diagnostik, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
-		return nil, fmt.Errorf("gagal parsing HTML: %w", err)
+		return nil, nil, fmt.Errorf("gagal parsing HTML: %w", err)
+	}
+
+	return ParseDokumen(doc, opsi)
+}
+
+// ParseDokumen mengurai dokumen goquery menjadi struktur Definisi, beserta
+// diagnostik untuk bagian halaman yang tidak dikenali
+//
+// Dalam mode ketat, adanya diagnostik menghasilkan *KesalahanParsing;
+// Definisi tetap dikembalikan agar bagian yang berhasil diurai bisa dipakai.
+func ParseDokumen(doc *goquery.Document, opsi Opsi) (*model.Definisi, []Diagnostik, error) {
+	terautentikasi := opsi.Tampilan == TampilanPengguna
+	if opsi.Tampilan == TampilanOtomatis {
+		terautentikasi = doc.Find("#loginLink").Length() == 0
 	}
 
 	definisi := &model.Definisi{
@@ -22,20 +88,50 @@ func ParseDefinisi(html string, terautentikasi bool) (*model.Definisi, error) {
 		Idiom:      []string{},
 		SaranEntri: []string{},
 	}
+	c := &catatan{}
 
 	// Cek apakah ada saran entri (ketika entri tidak ditemukan)
-	if strings.Contains(html, "Berikut beberapa saran entri lain yang mirip.") {
+	if strings.Contains(doc.Text(), "Berikut beberapa saran entri lain yang mirip.") {
 		definisi.SaranEntri = parseSaranEntri(doc)
-		return definisi, nil
+		if len(definisi.SaranEntri) == 0 {
+			c.struktur(".col-md-3", "halaman saran tidak berisi saran entri", nil)
+		}
+		return selesai(definisi, c, opsi.Ketat)
+	}
+
+	if doc.Find("hr").Length() == 0 {
+		c.struktur("hr", "penanda awal daftar entri tidak ditemukan", nil)
 	}
 
 	// Parse entri normal
-	definisi.Entri = parseEntriList(doc, terautentikasi)
+	var lampiran []model.Lampiran
+	definisi.Entri, lampiran = parseEntriList(doc, terautentikasi, c)
+	if !opsi.TanpaLampiran {
+		definisi.Lampiran = lampiran
+	}
 	
-	// Parse Peribahasa dan Idiom di level definisi
-	parsePeribahawanIdiom(doc, definisi)
+	// Kumpulkan Peribahasa dan Idiom di level definisi
+	kumpulkanUngkapan(definisi)
+
+	if len(definisi.Entri) == 0 {
+		c.struktur("h2", "halaman tidak berisi entri maupun saran entri", nil)
+	}
 	
-	return definisi, nil
+	return selesai(definisi, c, opsi.Ketat)
+}
+
+// selesai mengembalikan hasil parsing, mengubah diagnostik TingkatStruktur
+// menjadi error dalam mode ketat
+func selesai(definisi *model.Definisi, c *catatan, ketat bool) (*model.Definisi, []Diagnostik, error) {
+	if !ketat || !c.fatal() {
+		return definisi, c.daftar, nil
+	}
+
+	err := ErrTataLetak
+	if len(definisi.Entri) == 0 && len(definisi.SaranEntri) == 0 {
+		err = ErrTanpaEntri
+	}
+	return definisi, c.daftar, &KesalahanParsing{Diagnostik: c.daftar, Err: err}
 }
 
 // parseSaranEntri mengurai saran entri dari HTML
@@ -52,41 +148,48 @@ func parseSaranEntri(doc *goquery.Document) []string {
 	return saranEntri
 }
 
-// parseEntriList mengurai daftar entri dari HTML
-func parseEntriList(doc *goquery.Document, terautentikasi bool) []model.Entri {
+// parseEntriList mengurai daftar entri dan lampiran dari HTML
+func parseEntriList(doc *goquery.Document, terautentikasi bool, c *catatan) ([]model.Entri, []model.Lampiran) {
 	var entris []model.Entri
+	var lampiran []model.Lampiran
 	var currentEntri strings.Builder
 	var finished bool
+
+	// Lampiran diawali h2 dengan style="color:gray" dan berlanjut hingga
+	// h2 berikutnya
+	dalamLampiran := false
+
+	// simpan mengurai bagian yang sedang dikumpulkan sebagai entri atau
+	// lampiran
+	simpan := func() {
+		if currentEntri.Len() == 0 {
+			return
+		}
+		if dalamLampiran {
+			lampiran = append(lampiran, parseLampiran(currentEntri.String(), c))
+			return
+		}
+		entri := parseEntri(currentEntri.String(), terautentikasi, c)
+		if entri.Nama != "" {
+			entris = append(entris, entri)
+		}
+	}
 	
 	// Cari elemen hr pertama sebagai penanda awal
 	doc.Find("hr").First().NextAll().Each(func(i int, s *goquery.Selection) {
 		// Jika menemukan hr tanpa style, itu penanda akhir
 		if goquery.NodeName(s) == "hr" && s.AttrOr("style", "") == "" {
-			if currentEntri.Len() > 0 {
-				entri := parseEntri(currentEntri.String(), terautentikasi)
-				if entri.Nama != "" {
-					entris = append(entris, entri)
-				}
-			}
+			simpan()
 			finished = true
 			return
 		}
 
-		// Jika menemukan h2, itu awal entri baru
+		// Jika menemukan h2, itu awal entri atau lampiran baru
 		if goquery.NodeName(s) == "h2" {
 			// Simpan entri sebelumnya jika ada
-			if currentEntri.Len() > 0 {
-				entri := parseEntri(currentEntri.String(), terautentikasi)
-				if entri.Nama != "" {
-					entris = append(entris, entri)
-				}
-				currentEntri.Reset()
-			}
-			
-			// Skip jika ini adalah lampiran (style="color:gray")
-			if s.AttrOr("style", "") == "color:gray" {
-				return
-			}
+			simpan()
+			currentEntri.Reset()
+			dalamLampiran = s.AttrOr("style", "") == "color:gray"
 		}
 
 		// Tambahkan HTML ke current entri
@@ -95,20 +198,21 @@ func parseEntriList(doc *goquery.Document, terautentikasi bool) []model.Entri {
 	})
 
 	// Proses entri terakhir hanya jika belum diproses
-	if !finished && currentEntri.Len() > 0 {
-		entri := parseEntri(currentEntri.String(), terautentikasi)
-		if entri.Nama != "" {
-			entris = append(entris, entri)
-		}
+	if !finished {
+		simpan()
 	}
 
-	return entris
+	return entris, lampiran
 }
 
 // parseEntri mengurai satu entri dari HTML
-func parseEntri(htmlEntri string, terautentikasi bool) model.Entri {
+func parseEntri(htmlEntri string, terautentikasi bool, c *catatan) model.Entri {
+	c.entri = ""
+	defer func() { c.entri = "" }()
+
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlEntri))
 	if err != nil {
+		c.struktur("entri", fmt.Sprintf("gagal parsing HTML entri: %v", err), nil)
 		return model.Entri{}
 	}
 
@@ -116,29 +220,46 @@ func parseEntri(htmlEntri string, terautentikasi bool) model.Entri {
 		KataDasar:       []string{},
 		Varian:          []string{},
 		BentukTidakBaku: []string{},
+		SukuKata:        []string{},
 		Makna:           []model.Makna{},
 	}
 
 	// Parse header entri (h2)
 	judul := doc.Find("h2").First()
 	if judul.Length() == 0 {
+		c.struktur("h2", "bagian tanpa judul entri dilewati", doc.Find("body").Children())
 		return entri
 	}
 
 	parseNamaEntri(judul, &entri)
 	parseNomorEntri(judul, &entri)
+	if entri.Nama == "" {
+		c.struktur("h2", "nama entri tidak ditemukan, entri dilewati", judul)
+		return entri
+	}
+	c.entri = entri.Nama
+	if entri.Nomor != "" {
+		c.entri = fmt.Sprintf("%s (%s)", entri.Nama, entri.Nomor)
+	}
+	lengkapiLema(&entri)
+	lengkapiIdentitas(&entri)
 	parseKataDasar(judul, &entri)
 	parsePelafalan(judul, &entri)
 	parseVarian(judul, &entri, terautentikasi)
 
+	// Field teks tetap diisi agar keluaran lama tidak berubah
+	entri.KataDasar = model.TeksRujukan(entri.KataDasarRujukan)
+	entri.Varian = model.TeksRujukan(entri.VarianRujukan)
+	entri.BentukTidakBaku = model.TeksRujukan(entri.BentukTidakBakuRujukan)
+
 	// Parse etimologi jika terautentikasi
 	if terautentikasi {
-		parseEtimologi(doc, &entri)
+		parseEtimologi(doc, &entri, c)
 		parseTerkait(doc, &entri)
 	}
 
 	// Parse makna
-	parseMakna(doc, &entri, terautentikasi)
+	parseMakna(doc, &entri, terautentikasi, c)
 
 	return entri
 }
@@ -177,12 +298,7 @@ func parseKataDasar(judul *goquery.Selection, entri *model.Entri) {
 	judul.Find(".rootword").Each(func(i int, s *goquery.Selection) {
 		link := s.Find("a")
 		if link.Length() > 0 {
-			kata := ambilTeksDalamLabel(link)
-			nomor := link.Find("sup")
-			if nomor.Length() > 0 {
-				kata = fmt.Sprintf("%s (%s)", kata, strings.TrimSpace(nomor.Text()))
-			}
-			entri.KataDasar = append(entri.KataDasar, kata)
+			entri.KataDasarRujukan = append(entri.KataDasarRujukan, buatRujukan(link.First(), model.RujukanKataDasar))
 		}
 	})
 }
@@ -192,6 +308,7 @@ func parsePelafalan(judul *goquery.Selection, entri *model.Entri) {
 	lafal := judul.Find(".syllable")
 	if lafal.Length() > 0 {
 		entri.Pelafalan = strings.TrimSpace(lafal.Text())
+		entri.Lafal = parseLafal(entri.Pelafalan)
 	}
 }
 
@@ -223,141 +340,291 @@ func parseVarian(judul *goquery.Selection, entri *model.Entri, terautentikasi bo
 	bentukTidakBaku := varian.Find("b")
 	if bentukTidakBaku.Length() > 0 {
 		bentukTidakBaku.Each(func(i int, s *goquery.Selection) {
-			nama := strings.TrimSpace(s.Text())
-			nama = strings.TrimLeft(nama, ", ")
-			
-			nomor := s.Find("sup")
-			if nomor.Length() > 0 {
-				nama = fmt.Sprintf("%s (%s)", nama, strings.TrimSpace(nomor.Text()))
+			// Gunakan tautan di dalam b jika ada, agar URL tujuan ikut terambil
+			sumber := s
+			if link := s.Find("a").First(); link.Length() > 0 {
+				sumber = link
 			}
-			entri.BentukTidakBaku = append(entri.BentukTidakBaku, nama)
+			entri.BentukTidakBakuRujukan = append(entri.BentukTidakBakuRujukan, buatRujukan(sumber, model.RujukanBentukTidakBaku))
+		})
+	} else if link := varian.Find("a"); link.Length() > 0 {
+		// Varian dengan tautan ke entrinya
+		link.Each(func(i int, s *goquery.Selection) {
+			entri.VarianRujukan = append(entri.VarianRujukan, buatRujukan(s, model.RujukanVarian))
 		})
 	} else {
 		// Varian biasa
 		text := strings.TrimSpace(varian.Text())
 		if strings.HasPrefix(text, "varian: ") {
 			varianText := strings.TrimPrefix(text, "varian: ")
-			entri.Varian = strings.Split(varianText, ", ")
-			// Trim setiap varian
-			for i, v := range entri.Varian {
-				entri.Varian[i] = strings.TrimSpace(v)
+			for _, v := range strings.Split(varianText, ", ") {
+				kata := strings.TrimSpace(v)
+				entri.VarianRujukan = append(entri.VarianRujukan, model.Rujukan{
+					Kata:  kata,
+					Jenis: model.RujukanVarian,
+					URL:   urlRujukan(nil, kata),
+				})
 			}
 		}
 	}
 }
 
 // parseEtimologi mengurai etimologi
-func parseEtimologi(doc *goquery.Document, entri *model.Entri) {
-	// Cari text "Etimologi:"
-	doc.Find("*").Each(func(i int, s *goquery.Selection) {
-		if strings.Contains(s.Text(), "Etimologi:") {
-			// Ambil sibling berikutnya
-			next := s.Next()
-			if next.Length() > 0 {
-				etimologiHTML, _ := next.Html()
-				etimologi := parseEtimologiHTML(etimologiHTML)
-				if etimologi != nil {
-					entri.Etimologi = etimologi
-				}
-			}
+func parseEtimologi(doc *goquery.Document, entri *model.Entri, c *catatan) {
+	// Cari elemen terdalam yang memuat "Etimologi:" agar etimologi hanya
+	// diurai sekali, bukan untuk setiap leluhurnya
+	penanda := doc.Find("*").FilterFunction(func(i int, s *goquery.Selection) bool {
+		if !strings.Contains(s.Text(), "Etimologi:") {
+			return false
 		}
-	})
+		return s.Children().FilterFunction(func(j int, anak *goquery.Selection) bool {
+			return strings.Contains(anak.Text(), "Etimologi:")
+		}).Length() == 0
+	}).First()
+
+	if penanda.Length() == 0 {
+		return
+	}
+
+	if next := penanda.Next(); next.Length() > 0 {
+		entri.Etimologi = parseEtimologiTahap(next)
+	}
+
+	if entri.Etimologi == nil {
+		c.peringatan("Etimologi:", "etimologi ditemukan tetapi tidak dapat diurai", penanda.Parent())
+	}
 }
 
-// parseEtimologiHTML mengurai HTML etimologi
-func parseEtimologiHTML(html string) *model.Etimologi {
-	// Hapus kurung siku di awal dan akhir
-	html = strings.TrimPrefix(html, "[")
-	html = strings.TrimSuffix(html, "]")
+// parseEtimologiTahap mengurai etimologi menjadi rantai tahap asal kata
+//
+// Setiap tahap diawali nama bahasa (i dengan style color:darkred), diikuti
+// kelas kata, bentuk asal (b), pelafalan atau alih aksara, dan arti dalam
+// tanda petik. Teks arti dikumpulkan per tahap dari semua node, termasuk
+// cetak miring atau tebal di dalam petik, lalu diurai setelah penjelajahan.
+func parseEtimologiTahap(s *goquery.Selection) *model.Etimologi {
+	var tahap []model.TahapEtimologi
+	var teksArti []string
+
+	// saatIni mengembalikan indeks tahap terakhir, membuat tahap baru jika
+	// belum ada atau bila bagian yang akan diisi sudah terisi
+	saatIni := func(baru bool) int {
+		if len(tahap) == 0 || baru {
+			tahap = append(tahap, model.TahapEtimologi{Kelas: []string{}, Arti: []string{}})
+			teksArti = append(teksArti, "")
+		}
+		return len(tahap) - 1
+	}
+	terisi := func() bool {
+		if len(tahap) == 0 {
+			return false
+		}
+		t := tahap[len(tahap)-1]
+		return t.Bahasa != "" || t.AsalKata != "" || bersihkanArti(teksArti[len(tahap)-1]) != ""
+	}
+	// dalamPetik memeriksa apakah teks arti tahap terakhir berakhir di
+	// dalam tanda petik yang belum ditutup
+	dalamPetik := func() bool {
+		if len(tahap) == 0 {
+			return false
+		}
+		_, _, terbuka := pisahkanPetik(teksArti[len(tahap)-1])
+		return terbuka
+	}
 
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
-	if err != nil {
+	var jelajahi func(node *goquery.Selection)
+	jelajahi = func(node *goquery.Selection) {
+		node.Contents().Each(func(i int, el *goquery.Selection) {
+			gaya := strings.ReplaceAll(el.AttrOr("style", ""), " ", "")
+			teks := strings.TrimSpace(el.Text())
+
+			switch {
+			case goquery.NodeName(el) == "#text":
+				if len(tahap) > 0 || bersihkanArti(el.Text()) != "" {
+					teksArti[saatIni(false)] += el.Text()
+				}
+			case strings.Contains(gaya, "color:darkred"):
+				tahap[saatIni(terisi())].Bahasa = teks
+			case strings.Contains(gaya, "color:red"):
+				t := &tahap[saatIni(false)]
+				if teks != "" {
+					t.Kelas = append(t.Kelas, teks)
+				}
+			case strings.Contains(gaya, "color:darkgreen"):
+				tahap[saatIni(false)].Pelafalan = teks
+			case goquery.NodeName(el) == "b" && dalamPetik():
+				// Cetak tebal di dalam petik adalah bagian arti
+				teksArti[len(tahap)-1] += el.Text()
+			case goquery.NodeName(el) == "b":
+				n := saatIni(false)
+				if tahap[n].AsalKata != "" || bersihkanArti(teksArti[n]) != "" {
+					n = saatIni(true)
+				}
+				tahap[n].AsalKata = teks
+			default:
+				jelajahi(el)
+			}
+		})
+	}
+	jelajahi(s)
+
+	if len(tahap) == 0 {
 		return nil
 	}
 
-	etimologi := &model.Etimologi{
-		Kelas: []string{},
-		Arti:  []string{},
+	// Arti diambil dari teks dalam petik; jika tidak ada petik, sisa teks
+	// tahap dianggap sebagai arti
+	for i, teks := range teksArti {
+		kutipan, sisa, _ := pisahkanPetik(teks)
+		if len(kutipan) == 0 {
+			kutipan = []string{bersihkanArti(sisa)}
+		}
+		for _, k := range kutipan {
+			for _, arti := range strings.Split(k, ";") {
+				if arti = strings.TrimSpace(arti); arti != "" {
+					tahap[i].Arti = append(tahap[i].Arti, arti)
+				}
+			}
+		}
 	}
 
-	// Parse bahasa (i dengan style color:darkred)
-	doc.Find(`i[style*="color:darkred"]`).Each(func(i int, s *goquery.Selection) {
-		etimologi.Bahasa = strings.TrimSpace(s.Text())
-	})
+	pertama := tahap[0]
+	return &model.Etimologi{
+		Kelas:     pertama.Kelas,
+		Bahasa:    pertama.Bahasa,
+		AsalKata:  pertama.AsalKata,
+		Pelafalan: pertama.Pelafalan,
+		Arti:      pertama.Arti,
+		Tahap:     tahap,
+	}
+}
 
-	// Parse kelas (span dengan style color:red)
-	doc.Find(`span[style*="color:red"]`).Each(func(i int, s *goquery.Selection) {
-		kelas := strings.TrimSpace(s.Text())
-		if kelas != "" {
-			etimologi.Kelas = append(etimologi.Kelas, kelas)
+// pisahkanPetik memisahkan teks di dalam tanda petik dari sisanya
+//
+// Tanda petik tunggal di antara dua huruf adalah apostrof (Jum'at, ma'na)
+// dan bukan pembatas arti. terbuka bernilai true jika teks berakhir di dalam
+// petik yang belum ditutup.
+func pisahkanPetik(teks string) (kutipan []string, sisa string, terbuka bool) {
+	huruf := []rune(teks)
+	var dalam, luar strings.Builder
+
+	for i, r := range huruf {
+		if !strings.ContainsRune(`'‘’"“”`, r) || apostrof(huruf, i) {
+			if terbuka {
+				dalam.WriteRune(r)
+			} else {
+				luar.WriteRune(r)
+			}
+			continue
 		}
-	})
 
-	// Parse asal kata (b)
-	doc.Find("b").Each(func(i int, s *goquery.Selection) {
-		etimologi.AsalKata = strings.TrimSpace(s.Text())
-	})
+		if terbuka {
+			kutipan = append(kutipan, dalam.String())
+			dalam.Reset()
+		}
+		terbuka = !terbuka
+	}
 
-	// Parse pelafalan (span dengan style color:darkgreen)
-	doc.Find(`span[style*="color:darkgreen"]`).Each(func(i int, s *goquery.Selection) {
-		etimologi.Pelafalan = strings.TrimSpace(s.Text())
-	})
+	if terbuka {
+		luar.WriteString(dalam.String())
+	}
+	return kutipan, luar.String(), terbuka
+}
 
-	// Parse arti (ambil text keseluruhan dan bersihkan)
-	text := doc.Text()
-	text = strings.Trim(text, `'"`)
-	if strings.Contains(text, "; ") {
-		etimologi.Arti = strings.Split(text, "; ")
-		// Trim setiap arti
-		for i, arti := range etimologi.Arti {
-			etimologi.Arti[i] = strings.TrimSpace(arti)
-		}
-	} else if text != "" {
-		etimologi.Arti = []string{strings.TrimSpace(text)}
+// apostrof memeriksa apakah tanda petik tunggal pada posisi i diapit huruf
+func apostrof(huruf []rune, i int) bool {
+	if huruf[i] == '"' || huruf[i] == '“' || huruf[i] == '”' {
+		return false
 	}
+	return i > 0 && i+1 < len(huruf) && unicode.IsLetter(huruf[i-1]) && unicode.IsLetter(huruf[i+1])
+}
 
-	return etimologi
+// bersihkanArti membuang kurung, tanda petik, dan tanda baca di tepi teks
+// arti
+func bersihkanArti(teks string) string {
+	return strings.TrimFunc(teks, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(`[]()'‘’"“”,;:<`, r)
+	})
 }
 
 // parseTerkait mengurai kata terkait
 func parseTerkait(doc *goquery.Document, entri *model.Entri) {
-	// Mapping header ke field
-	headerMap := map[string]*[]string{
-		"Kata Turunan":   &entri.KataTurunan,
-		"Gabungan Kata":  &entri.GabunganKata,
-	}
-
 	doc.Find("h4").Each(func(i int, s *goquery.Selection) {
 		headerText := strings.TrimSpace(s.Text())
-		
-		for header, field := range headerMap {
-			if strings.Contains(headerText, header) {
-				// Ambil link-link di sibling berikutnya
-				next := s.Next()
-				if next.Length() > 0 {
-					next.Find("a").Each(func(j int, link *goquery.Selection) {
-						text := strings.TrimSpace(link.Text())
-						if text != "" {
-							*field = append(*field, text)
-						}
-					})
-				}
-				break
-			}
+
+		// Setiap bagian disimpan pada Terkait, termasuk bagian yang tidak
+		// memiliki field sendiri; bagian kosong wajar ditemui dan tidak
+		// dicatat sebagai diagnostik
+		bagian := parseBagianTerkait(s, headerText)
+		entri.Terkait = append(entri.Terkait, bagian)
+
+		// Peribahasa dan idiom diurai beserta maknanya
+		if strings.Contains(headerText, "Peribahasa") {
+			entri.Peribahasa = append(entri.Peribahasa, parseUngkapan(s, model.UngkapanPeribahasa, entri)...)
+			return
+		}
+		if strings.Contains(headerText, "Idiom") {
+			entri.Idiom = append(entri.Idiom, parseUngkapan(s, model.UngkapanIdiom, entri)...)
+			return
+		}
+
+		// Kata turunan dan gabungan kata diambil dari bagian yang sama agar
+		// tidak berbeda dengan Terkait
+		if strings.Contains(headerText, "Kata Turunan") {
+			entri.KataTurunan = append(entri.KataTurunan, model.KataRujukan(bagian.Kata)...)
+		} else if strings.Contains(headerText, "Gabungan Kata") {
+			entri.GabunganKata = append(entri.GabunganKata, model.KataRujukan(bagian.Kata)...)
 		}
 	})
 }
 
+// parseBagianTerkait mengurai kata-kata pada daftar setelah judul h4;
+// butir tanpa tautan diambil dari teksnya
+func parseBagianTerkait(judul *goquery.Selection, teksJudul string) model.BagianTerkait {
+	bagian := model.BagianTerkait{Judul: teksJudul, Kata: []model.Rujukan{}}
+
+	daftar := judul.Next()
+	if daftar.Length() == 0 || goquery.NodeName(daftar) == "h4" {
+		return bagian
+	}
+
+	daftar.Find("a").Each(func(i int, link *goquery.Selection) {
+		if rujukan := buatRujukan(link, model.RujukanTerkait); rujukan.Kata != "" {
+			bagian.Kata = append(bagian.Kata, rujukan)
+		}
+	})
+	if len(bagian.Kata) > 0 {
+		return bagian
+	}
+
+	daftar.Find("li").Each(func(i int, li *goquery.Selection) {
+		kata, _, _ := strings.Cut(li.Text(), ":")
+		if kata = strings.TrimSpace(kata); kata != "" {
+			bagian.Kata = append(bagian.Kata, model.Rujukan{
+				Kata:  kata,
+				Jenis: model.RujukanTerkait,
+				URL:   urlRujukan(nil, kata),
+			})
+		}
+	})
+
+	return bagian
+}
+
 // parseMakna mengurai makna-makna entri
-func parseMakna(doc *goquery.Document, entri *model.Entri, terautentikasi bool) {
+func parseMakna(doc *goquery.Document, entri *model.Entri, terautentikasi bool, c *catatan) {
+	lema := entri.Lema
+
 	// Cari makna prakategorial (dengan color="darkgreen")
 	prakategorial := doc.Find(`[color="darkgreen"]`)
 	if prakategorial.Length() > 0 {
-		makna := parseMaknaSingle(prakategorial.First())
+		makna := parseMaknaSingle(prakategorial.First(), lema, c)
 		entri.Makna = append(entri.Makna, makna)
 		return
 	}
 
+	// Entri yang hanya berisi rujukan memang tidak memiliki makna sendiri
+	adaRujukan := false
+
 	// Parse makna dari li
 	doc.Find("li").Each(func(i int, s *goquery.Selection) {
 		// Skip jika terautentikasi dan mengandung "Usulkan makna baru"
@@ -365,38 +632,134 @@ func parseMakna(doc *goquery.Document, entri *model.Entri, terautentikasi bool)
 			return
 		}
 
+		// Skip butir daftar kata terkait, peribahasa, dan idiom
+		if goquery.NodeName(s.Parent().Prev()) == "h4" {
+			return
+		}
+
+		// Makna anak diurai bersama makna induknya
+		if s.ParentsFiltered("li").Length() > 0 {
+			return
+		}
+
 		// Skip jika li hanya berisi rujukan internal (dimulai dengan →)
 		text := strings.TrimSpace(s.Text())
 		if strings.HasPrefix(text, "→") {
+			adaRujukan = true
 			return
 		}
 
-		makna := parseMaknaSingle(s)
+		makna := parseMaknaBertingkat(s, lema, c)
 		
-		// Filter tambahan: skip jika makna hanya berisi submakna dengan rujukan
-		if len(makna.Submakna) > 0 {
-			isAllRujukan := true
-			for _, submakna := range makna.Submakna {
-				if !strings.HasPrefix(strings.TrimSpace(submakna), "→") {
-					isAllRujukan = false
-					break
-				}
-			}
-			// Skip jika semua submakna adalah rujukan dan tidak ada kelas kata
-			if isAllRujukan && len(makna.Kelas) == 0 {
-				return
-			}
-			
+		// Filter tambahan: skip jika makna hanya berisi rujukan tanpa kelas kata
+		if len(makna.Submakna) == len(makna.Rujukan) && len(makna.Rujukan) > 0 && len(makna.Kelas) == 0 {
+			adaRujukan = true
+			return
+		}
+
+		if maknaBerisi(makna) {
 			entri.Makna = append(entri.Makna, makna)
+		} else if text != "" {
+			c.peringatan("li", "makna tanpa isi dilewati", s)
 		}
 	})
+
+	if len(entri.Makna) == 0 && !adaRujukan {
+		c.struktur("li", "entri tidak memiliki makna", nil)
+	}
+}
+
+// parseMaknaBertingkat mengurai makna beserta makna anak dari daftar
+// bersarang di dalamnya (misalnya a, b, c di bawah makna 1)
+func parseMaknaBertingkat(s *goquery.Selection, lema string, c *catatan) model.Makna {
+	// Makna induk diurai tanpa daftar bersarang agar isi makna anak tidak
+	// ikut terbaca
+	induk := s.Clone()
+	induk.Find("ol, ul").Remove()
+	makna := parseMaknaSingle(induk, lema, c)
+	makna.Nomor = nomorMakna(s)
+
+	s.Find("li").Each(func(i int, li *goquery.Selection) {
+		if !li.ParentsFiltered("li").First().IsSelection(s) {
+			return
+		}
+
+		anak := parseMaknaBertingkat(li, lema, c)
+		if maknaBerisi(anak) {
+			makna.Anak = append(makna.Anak, anak)
+		} else if strings.TrimSpace(li.Text()) != "" {
+			c.peringatan("li li", "makna anak tanpa isi dilewati", li)
+		}
+	})
+
+	return makna
+}
+
+// maknaBerisi memeriksa apakah makna memiliki isi untuk ditampilkan
+func maknaBerisi(makna model.Makna) bool {
+	return len(makna.Submakna) > 0 || len(makna.Rujukan) > 0 || len(makna.Anak) > 0
+}
+
+// nomorMakna mengembalikan penomoran asli butir li sesuai atribut start,
+// type, dan value pada daftar ol; butir ul tidak bernomor
+func nomorMakna(li *goquery.Selection) string {
+	daftar := li.Parent()
+	if goquery.NodeName(daftar) != "ol" {
+		return ""
+	}
+
+	nomor := 1
+	if mulai, err := strconv.Atoi(daftar.AttrOr("start", "")); err == nil {
+		nomor = mulai
+	}
+	nomor += li.PrevAllFiltered("li").Length()
+	if nilai, err := strconv.Atoi(li.AttrOr("value", "")); err == nil {
+		nomor = nilai
+	}
+
+	switch daftar.AttrOr("type", "") {
+	case "a":
+		if nomor >= 1 && nomor <= 26 {
+			return string(rune('a' + nomor - 1))
+		}
+	case "A":
+		if nomor >= 1 && nomor <= 26 {
+			return string(rune('A' + nomor - 1))
+		}
+	}
+
+	return strconv.Itoa(nomor)
+}
+
+// buatKelas menyusun kelas kata beserta jenis bakunya, mencatat peringatan
+// untuk kode yang tidak dikenal
+func buatKelas(kode, nama, deskripsi, konteks string, el *goquery.Selection, c *catatan) model.KelasKata {
+	jenis := model.CariKelas(kode)
+	if jenis == model.KelasTidakDikenal {
+		jenis = model.CariKelas(nama)
+	}
+	if jenis == model.KelasTidakDikenal {
+		c.info(konteks, fmt.Sprintf("kelas kata %q tidak dikenal", kode), el)
+	}
+
+	if nama == "" && jenis != model.KelasTidakDikenal {
+		nama = jenis.Nama()
+	}
+
+	return model.KelasKata{
+		Jenis:     jenis,
+		Kode:      kode,
+		Nama:      nama,
+		Deskripsi: deskripsi,
+	}
 }
 
 // parseMaknaSingle mengurai satu makna
-func parseMaknaSingle(s *goquery.Selection) model.Makna {
+func parseMaknaSingle(s *goquery.Selection, lema string, c *catatan) model.Makna {
 	makna := model.Makna{
 		Kelas:    []model.KelasKata{},
 		Submakna: []string{},
+		Label:    []model.Label{},
 		Contoh:   []string{},
 	}
 
@@ -408,6 +771,13 @@ func parseMaknaSingle(s *goquery.Selection) model.Makna {
 		kelasElement.Find("span").Each(func(j int, span *goquery.Selection) {
 			kode := strings.TrimSpace(span.Text())
 			title := span.AttrOr("title", "")
+
+			// Label pemakaian (mis. ki) kadang ikut ditulis merah bersama
+			// kelas kata
+			if _, dikenal := model.CariLabel(kode); dikenal {
+				makna.Label = append(makna.Label, buatLabel(kode, title, span, c))
+				return
+			}
 			
 			parts := strings.Split(title, ": ")
 			nama := ""
@@ -420,12 +790,12 @@ func parseMaknaSingle(s *goquery.Selection) model.Makna {
 				deskripsi = strings.TrimSpace(parts[1])
 			}
 
+			if kode != "" && title == "" {
+				c.info(`[color="red"] span`, fmt.Sprintf("kelas kata %q tanpa keterangan", kode), span)
+			}
+
 			if kode != "" {
-				makna.Kelas = append(makna.Kelas, model.KelasKata{
-					Kode:      kode,
-					Nama:      nama,
-					Deskripsi: deskripsi,
-				})
+				makna.Kelas = append(makna.Kelas, buatKelas(kode, nama, deskripsi, `[color="red"] span`, span, c))
 			}
 		})
 	})
@@ -446,16 +816,14 @@ func parseMaknaSingle(s *goquery.Selection) model.Makna {
 			deskripsi = strings.TrimSpace(parts[1])
 		}
 
-		makna.Kelas = append(makna.Kelas, model.KelasKata{
-			Kode:      kode,
-			Nama:      nama,
-			Deskripsi: deskripsi,
-		})
+		makna.Kelas = append(makna.Kelas, buatKelas(kode, nama, deskripsi, `[color="darkgreen"]`, s, c))
 	}
 
 	// Parse info tambahan (color="green")
 	info := s.Find(`[color="green"]`)
 	if info.Length() > 0 {
+		makna.Label = append(makna.Label, parseLabel(info, c)...)
+
 		infoText := strings.TrimSpace(info.Text())
 		// Pastikan info tidak duplikat dengan kelas
 		isDuplicate := false
@@ -473,12 +841,13 @@ func parseMaknaSingle(s *goquery.Selection) model.Makna {
 	// Parse rujukan (link a tanpa span style color:red)
 	rujukan := s.Find("a")
 	if rujukan.Length() > 0 && rujukan.Find(`span[style*="color:red"]`).Length() == 0 {
-		rujukanText := ambilTeksDalamLabel(rujukan)
-		nomor := rujukan.Find("sup")
-		if nomor.Length() > 0 {
-			rujukanText = fmt.Sprintf("%s (%s)", rujukanText, strings.TrimSpace(nomor.Text()))
-		}
-		makna.Submakna = append(makna.Submakna, fmt.Sprintf("→ %s", rujukanText))
+		// Rujukan juga ditulis pada Submakna ("→ kata (n)") seperti
+		// sebelumnya agar keluaran lama tidak berubah
+		rujukan.Each(func(i int, link *goquery.Selection) {
+			r := buatRujukan(link, model.RujukanMakna)
+			makna.Rujukan = append(makna.Rujukan, r)
+			makna.Submakna = append(makna.Submakna, r.String())
+		})
 	} else if s.AttrOr("color", "") == "darkgreen" {
 		// Prakategorial
 		next := s.Get(0).NextSibling
@@ -512,18 +881,9 @@ func parseMaknaSingle(s *goquery.Selection) model.Makna {
 		}
 	}
 
-	// Parse contoh (setelah ": ")
-	fullText := s.Text()
-	if idx := strings.Index(fullText, ": "); idx != -1 {
-		contohText := strings.TrimSpace(fullText[idx+2:])
-		if contohText != "" {
-			makna.Contoh = strings.Split(contohText, "; ")
-			// Trim setiap contoh
-			for i, contoh := range makna.Contoh {
-				makna.Contoh[i] = strings.TrimSpace(contoh)
-			}
-		}
-	}
+	// Parse contoh dari elemen contoh (font abu-abu)
+	makna.ContohRinci = parseContoh(s, lema)
+	makna.Contoh = model.TeksContoh(makna.ContohRinci)
 
 	// Trim semua submakna
 	for i, sub := range makna.Submakna {
@@ -533,6 +893,43 @@ func parseMaknaSingle(s *goquery.Selection) model.Makna {
 	return makna
 }
 
+// buatRujukan membuat rujukan dari elemen berisi kata dan nomor homonim
+// (sup), umumnya sebuah tautan
+func buatRujukan(s *goquery.Selection, jenis model.JenisRujukan) model.Rujukan {
+	kata := ambilTeksDalamLabel(s)
+	if kata == "" {
+		salinan := s.Clone()
+		salinan.Find("sup").Remove()
+		kata = strings.TrimSpace(salinan.Text())
+	}
+	kata = strings.TrimLeft(kata, ", ")
+
+	return model.Rujukan{
+		Kata:  kata,
+		Nomor: strings.TrimSpace(s.Find("sup").First().Text()),
+		Jenis: jenis,
+		URL:   urlRujukan(s, kata),
+	}
+}
+
+// urlRujukan mengambil URL tujuan dari href tautan, atau membentuk URL
+// entri dari kata jika elemen bukan tautan
+func urlRujukan(s *goquery.Selection, kata string) string {
+	if s != nil {
+		if href, ada := s.Attr("href"); ada && href != "" {
+			if dasar, err := url.Parse(situs.Host() + "/"); err == nil {
+				if tujuan, err := dasar.Parse(href); err == nil {
+					return tujuan.String()
+				}
+			}
+		}
+	}
+	if kata == "" {
+		return ""
+	}
+	return situs.URLEntri(kata)
+}
+
 // ambilTeksDalamLabel mengambil text direct children dari element
 func ambilTeksDalamLabel(s *goquery.Selection) string {
 	var textParts []string
@@ -549,39 +946,8 @@ func ambilTeksDalamLabel(s *goquery.Selection) string {
 	return strings.Join(textParts, " ")
 }
 
-// parsePeribahawanIdiom mengurai Peribahasa dan Idiom di level definisi
-func parsePeribahawanIdiom(doc *goquery.Document, definisi *model.Definisi) {
-	doc.Find("h4").Each(func(i int, s *goquery.Selection) {
-		headerText := strings.TrimSpace(s.Text())
-		
-		if strings.Contains(headerText, "Peribahasa") {
-			// Ambil link-link di sibling berikutnya
-			next := s.Next()
-			if next.Length() > 0 {
-				next.Find("a").Each(func(j int, link *goquery.Selection) {
-					text := strings.TrimSpace(link.Text())
-					if text != "" {
-						definisi.Peribahasa = append(definisi.Peribahasa, text)
-					}
-				})
-			}
-		} else if strings.Contains(headerText, "Idiom") {
-			// Ambil link-link di sibling berikutnya
-			next := s.Next()
-			if next.Length() > 0 {
-				next.Find("a").Each(func(j int, link *goquery.Selection) {
-					text := strings.TrimSpace(link.Text())
-					if text != "" {
-						definisi.Idiom = append(definisi.Idiom, text)
-					}
-				})
-			}
-		}
-	})
-}
-
-// SetPranala mengatur pranala dalam definisi
+// SetPranala mengatur pranala dalam definisi ke halaman kata pada alamat
+// yang berlaku
 func SetPranala(d *model.Definisi, kata string) {
-	// Buat pranala berdasarkan kata
-	d.Pranala = fmt.Sprintf("https://kbbi.kemdikbud.go.id/entri/%s", kata)
-}
\ No newline at end of file
+	d.Pranala = situs.URLEntri(kata)
+}
@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/model"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
+)
+
+// pemisahSukuKata adalah tanda pemisah suku kata pada nama entri KBBI
+const pemisahSukuKata = "·"
+
+// lengkapiLema mengisi lema dan suku kata entri dari namanya
+func lengkapiLema(entri *model.Entri) {
+	nama := strings.TrimSpace(strings.TrimLeft(entri.Nama, "» "))
+	entri.Lema = strings.ReplaceAll(nama, pemisahSukuKata, "")
+	entri.SukuKata = pecahSukuKata(nama)
+	entri.JumlahSukuKata = len(entri.SukuKata)
+}
+
+// lengkapiIdentitas mengisi ID dan URL entri dari lema dan nomor homonimnya
+func lengkapiIdentitas(entri *model.Entri) {
+	entri.ID = model.IDEntri(entri.Lema, entri.Nomor)
+	entri.URL = situs.URLEntri(entri.Lema)
+}
+
+// parseLafal mengurai petunjuk pelafalan dari teks pelafalan seperti
+// "/ré·sap/"
+func parseLafal(pelafalan string) *model.Lafal {
+	teks := strings.TrimSpace(strings.Trim(strings.TrimSpace(pelafalan), "/"))
+	if teks == "" {
+		return nil
+	}
+
+	lafal := &model.Lafal{
+		Teks:     strings.ReplaceAll(teks, pemisahSukuKata, ""),
+		SukuKata: pecahSukuKata(teks),
+	}
+
+	for _, r := range strings.ToLower(teks) {
+		switch r {
+		case 'e':
+			lafal.BunyiE = append(lafal.BunyiE, model.EPepet)
+		case 'é':
+			lafal.BunyiE = append(lafal.BunyiE, model.ETaling)
+		case 'è':
+			lafal.BunyiE = append(lafal.BunyiE, model.ETerbuka)
+		}
+	}
+
+	return lafal
+}
+
+// pecahSukuKata memecah teks menjadi suku kata pada pemisah suku kata,
+// spasi, dan tanda hubung
+func pecahSukuKata(teks string) []string {
+	return strings.FieldsFunc(teks, func(r rune) bool {
+		return string(r) == pemisahSukuKata || r == ' ' || r == '-'
+	})
+}
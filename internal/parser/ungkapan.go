@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/model"
+)
+
+// parseUngkapan mengurai peribahasa atau idiom dari daftar setelah judul h4
+//
+// Makna ditulis langsung setelah tautan ungkapan pada sebagian halaman;
+// tanpa itu, Makna dibiarkan kosong dan bisa dilengkapi dari halaman yang
+// dituju URL.
+func parseUngkapan(judul *goquery.Selection, jenis model.JenisUngkapan, entri *model.Entri) []model.Ungkapan {
+	var hasil []model.Ungkapan
+
+	daftar := judul.Next()
+	if daftar.Length() == 0 {
+		return hasil
+	}
+
+	tambah := func(teks, makna string, tautan *goquery.Selection) {
+		teks = strings.TrimSpace(teks)
+		if teks == "" {
+			return
+		}
+
+		ungkapan := model.Ungkapan{
+			Teks:       teks,
+			Makna:      strings.Trim(strings.TrimSpace(makna), " ,:;-–"),
+			Jenis:      jenis,
+			URL:        urlRujukan(tautan, teks),
+			Entri:      entri.Nama,
+			NomorEntri: entri.Nomor,
+		}
+		hasil = append(hasil, ungkapan)
+	}
+
+	item := daftar.Find("li")
+	if item.Length() == 0 {
+		daftar.Find("a").Each(func(i int, tautan *goquery.Selection) {
+			tambah(tautan.Text(), "", tautan)
+		})
+		return hasil
+	}
+
+	item.Each(func(i int, li *goquery.Selection) {
+		tautan := li.Find("a").First()
+		if tautan.Length() == 0 {
+			teks, makna, _ := strings.Cut(li.Text(), ":")
+			tambah(teks, makna, nil)
+			return
+		}
+
+		teks := tautan.Text()
+		makna := strings.Replace(li.Text(), teks, "", 1)
+		tambah(teks, makna, tautan)
+	})
+
+	return hasil
+}
+
+// kumpulkanUngkapan mengumpulkan teks ungkapan dari semua entri untuk
+// Definisi.Peribahasa dan Definisi.Idiom
+func kumpulkanUngkapan(definisi *model.Definisi) {
+	for _, entri := range definisi.Entri {
+		for _, u := range entri.Peribahasa {
+			definisi.Peribahasa = append(definisi.Peribahasa, u.Teks)
+		}
+		for _, u := range entri.Idiom {
+			definisi.Idiom = append(definisi.Idiom, u.Teks)
+		}
+	}
+}
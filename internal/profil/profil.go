@@ -0,0 +1,247 @@
+// Package profil menyediakan profil akun KBBI bernama, masing-masing dengan
+// kuki, rujukan kredensial, penghitung permintaan, dan cache sendiri
+package profil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/kredensial"
+)
+
+const (
+	// DirProfil adalah direktori profil relatif terhadap ~/.kbbi
+	DirProfil = "profil"
+
+	// NamaFileProfil adalah nama file pengaturan di dalam direktori profil
+	NamaFileProfil = "profil.json"
+
+	// NamaFileKuki adalah nama file kuki di dalam direktori profil
+	NamaFileKuki = "kuki.json"
+)
+
+var (
+	// ErrTidakDitemukan dikembalikan ketika profil belum dibuat
+	ErrTidakDitemukan = errors.New("profil tidak ditemukan")
+
+	// ErrSudahAda dikembalikan ketika profil dengan nama yang sama sudah ada
+	ErrSudahAda = errors.New("profil sudah ada")
+
+	// ErrNamaTidakValid dikembalikan ketika nama profil tidak dapat dipakai
+	// sebagai nama direktori
+	ErrNamaTidakValid = errors.New("nama profil hanya boleh berisi huruf, angka, titik, garis bawah, dan tanda hubung")
+)
+
+// polaNama membatasi nama profil agar aman sebagai nama direktori
+var polaNama = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*$`)
+
+// Profil berisi pengaturan satu akun KBBI
+//
+// Sandi tidak pernah disimpan di profil; profil hanya merujuk ke sumber
+// kredensial (email, file kredensial, atau pembantu kredensial).
+type Profil struct {
+	Nama               string    `json:"nama"`
+	Email              string    `json:"email,omitempty"`
+	FileKredensial     string    `json:"file_kredensial,omitempty"`
+	PembantuKredensial string    `json:"pembantu_kredensial,omitempty"`
+	CacheSendiri       bool      `json:"cache_sendiri"`
+	Dibuat             time.Time `json:"dibuat"`
+
+	// Direktori adalah lokasi direktori profil, diisi saat profil dimuat
+	Direktori string `json:"-"`
+}
+
+// DirektoriDasar mengembalikan direktori yang berisi semua profil
+// (~/.kbbi/profil)
+func DirektoriDasar() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("gagal mendapatkan home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".kbbi", DirProfil), nil
+}
+
+// ValidasiNama memeriksa apakah nama profil dapat dipakai
+func ValidasiNama(nama string) error {
+	if !polaNama.MatchString(nama) {
+		return fmt.Errorf("%w: %q", ErrNamaTidakValid, nama)
+	}
+	return nil
+}
+
+// direktoriProfil mengembalikan direktori untuk profil bernama
+func direktoriProfil(nama string) (string, error) {
+	if err := ValidasiNama(nama); err != nil {
+		return "", err
+	}
+	dasar, err := DirektoriDasar()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dasar, nama), nil
+}
+
+// Buat membuat profil baru beserta direktorinya
+func Buat(p Profil) (*Profil, error) {
+	dir, err := direktoriProfil(p.Nama)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, NamaFileProfil)); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSudahAda, p.Nama)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("gagal membuat direktori profil: %w", err)
+	}
+
+	p.Direktori = dir
+	if p.Dibuat.IsZero() {
+		p.Dibuat = time.Now()
+	}
+	if err := p.Simpan(); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Muat memuat profil bernama
+func Muat(nama string) (*Profil, error) {
+	dir, err := direktoriProfil(nama)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, NamaFileProfil))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrTidakDitemukan, nama)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca profil %s: %w", nama, err)
+	}
+
+	var p Profil
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("gagal membaca profil %s: %w", nama, err)
+	}
+
+	// Nama direktori adalah nama yang berlaku
+	p.Nama = nama
+	p.Direktori = dir
+	return &p, nil
+}
+
+// Daftar mengembalikan semua profil yang ada, diurutkan berdasarkan nama
+func Daftar() ([]*Profil, error) {
+	dasar, err := DirektoriDasar()
+	if err != nil {
+		return nil, err
+	}
+
+	entri, err := os.ReadDir(dasar)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca direktori profil: %w", err)
+	}
+
+	var daftar []*Profil
+	for _, e := range entri {
+		if !e.IsDir() || ValidasiNama(e.Name()) != nil {
+			continue
+		}
+		p, err := Muat(e.Name())
+		if errors.Is(err, ErrTidakDitemukan) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		daftar = append(daftar, p)
+	}
+
+	sort.Slice(daftar, func(i, j int) bool {
+		return daftar[i].Nama < daftar[j].Nama
+	})
+
+	return daftar, nil
+}
+
+// Hapus menghapus profil beserta kuki, penghitung permintaan, dan cache-nya
+//
+// Sesi di KBBI tidak diakhiri; keluar terlebih dahulu jika perlu.
+func Hapus(nama string) error {
+	p, err := Muat(nama)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(p.Direktori); err != nil {
+		return fmt.Errorf("gagal menghapus profil %s: %w", nama, err)
+	}
+	return nil
+}
+
+// Simpan menulis pengaturan profil ke profil.json
+func (p *Profil) Simpan() error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("gagal mengenkode profil: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(p.Direktori, NamaFileProfil), data, 0600); err != nil {
+		return fmt.Errorf("gagal menyimpan profil: %w", err)
+	}
+	return nil
+}
+
+// LokasiKuki mengembalikan lokasi file kuki profil
+func (p *Profil) LokasiKuki() string {
+	return filepath.Join(p.Direktori, NamaFileKuki)
+}
+
+// AdaKuki memeriksa apakah profil sudah memiliki kuki tersimpan
+func (p *Profil) AdaKuki() bool {
+	_, err := os.Stat(p.LokasiKuki())
+	return err == nil
+}
+
+// AcuanCache mengembalikan lokasi acuan untuk manager cache: lokasi kuki
+// profil jika profil memakai cache sendiri (cache di <profil>/cache), atau
+// kosong untuk cache bersama di ~/.kbbi/cache
+func (p *Profil) AcuanCache() string {
+	if p.CacheSendiri {
+		return p.LokasiKuki()
+	}
+	return ""
+}
+
+// Kredensial mengembalikan sumber kredensial yang dirujuk profil
+//
+// Urutannya: email profil sebagai petunjuk, pembantu kredensial profil,
+// file kredensial profil, lalu entri .netrc yang cocok dengan email.
+func (p *Profil) Kredensial() kredensial.Sumber {
+	return kredensial.Rantai(p.SumberKredensial()...)
+}
+
+// SumberKredensial mengembalikan daftar sumber kredensial profil sesuai
+// urutan prioritas, untuk disisipkan ke rantai sumber lain
+func (p *Profil) SumberKredensial() []kredensial.Sumber {
+	sumber := []kredensial.Sumber{kredensial.Tetap(p.Email, "", "profil "+p.Nama)}
+	if p.PembantuKredensial != "" {
+		sumber = append(sumber, kredensial.DariPembantu(p.PembantuKredensial))
+	}
+	if p.FileKredensial != "" {
+		sumber = append(sumber, kredensial.DariFile(p.FileKredensial))
+	}
+	return append(sumber, kredensial.DariNetrc(""))
+}
@@ -0,0 +1,136 @@
+// Package situs menyimpan alamat KBBI Daring dan jeda permintaan yang
+// dipakai bersama oleh paket auth dan fetcher
+//
+// Alamat dapat diarahkan ke server lain, misalnya server palsu dari paket
+// kbbitest, agar library dapat diuji tanpa menghubungi situs sebenarnya.
+package situs
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// HostBawaan adalah alamat KBBI Daring
+	HostBawaan = "https://kbbi.kemdikbud.go.id"
+
+	// JedaBawaan adalah jeda sebelum setiap permintaan halaman untuk
+	// menghindari pembatasan laju
+	JedaBawaan = 500 * time.Millisecond
+
+	// JedaUlangBawaan adalah satuan jeda antarpercobaan ulang; percobaan
+	// ke-n menunggu n kali nilai ini
+	JedaUlangBawaan = time.Second
+)
+
+// Pengaturan berisi alamat dan jeda yang dipakai library
+type Pengaturan struct {
+	// Host adalah alamat dasar tanpa garis miring di akhir, kosong berarti
+	// HostBawaan
+	Host string
+
+	// Jeda adalah jeda sebelum setiap permintaan halaman
+	Jeda time.Duration
+
+	// JedaUlang adalah satuan jeda antarpercobaan ulang
+	JedaUlang time.Duration
+}
+
+var (
+	mu    sync.RWMutex
+	aktif = Bawaan()
+)
+
+// Bawaan mengembalikan pengaturan untuk KBBI Daring
+func Bawaan() Pengaturan {
+	return Pengaturan{
+		Host:      HostBawaan,
+		Jeda:      JedaBawaan,
+		JedaUlang: JedaUlangBawaan,
+	}
+}
+
+// Sekarang mengembalikan pengaturan yang sedang berlaku
+func Sekarang() Pengaturan {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return aktif
+}
+
+// Atur mengganti pengaturan yang berlaku dan mengembalikan fungsi untuk
+// memulihkan pengaturan sebelumnya
+func Atur(p Pengaturan) (pulihkan func()) {
+	p.Host = strings.TrimRight(p.Host, "/")
+	if p.Host == "" {
+		p.Host = HostBawaan
+	}
+
+	mu.Lock()
+	sebelumnya := aktif
+	aktif = p
+	mu.Unlock()
+
+	return func() {
+		mu.Lock()
+		aktif = sebelumnya
+		mu.Unlock()
+	}
+}
+
+// Host mengembalikan alamat dasar yang berlaku
+func Host() string {
+	return Sekarang().Host
+}
+
+// URL menggabungkan alamat dasar dengan lokasi relatif
+func URL(lokasi string) string {
+	return Host() + "/" + strings.TrimLeft(lokasi, "/")
+}
+
+// LokasiEntri menentukan lokasi relatif halaman sebuah kata
+//
+// Kata dengan titik atau tanda tanya, serta "nul" dan "bin", tidak dapat
+// dibuka langsung di entri/ sehingga dicari melalui Cari/Hasil.
+func LokasiEntri(kata string) string {
+	// Kasus khusus yang memerlukan pencarian via Cari/Hasil
+	kasusKhusus := []bool{
+		strings.Contains(kata, "."),
+		strings.Contains(kata, "?"),
+		strings.ToLower(kata) == "nul",
+		strings.ToLower(kata) == "bin",
+	}
+
+	for _, kondisi := range kasusKhusus {
+		if kondisi {
+			return fmt.Sprintf("Cari/Hasil?frasa=%s", url.QueryEscape(kata))
+		}
+	}
+
+	// Kasus normal - akses langsung ke entri (termasuk kata dengan spasi)
+	// Gunakan PathEscape untuk URL path, bukan QueryEscape
+	return fmt.Sprintf("entri/%s", url.PathEscape(kata))
+}
+
+// URLEntri mengembalikan URL halaman sebuah kata pada alamat yang berlaku
+func URLEntri(kata string) string {
+	return URL(LokasiEntri(kata))
+}
+
+// HostBawaanAktif memeriksa apakah library mengarah ke KBBI Daring
+func HostBawaanAktif() bool {
+	return Host() == HostBawaan
+}
+
+// Jeda mengembalikan jeda sebelum setiap permintaan halaman
+func Jeda() time.Duration {
+	return Sekarang().Jeda
+}
+
+// JedaUlang mengembalikan satuan jeda antarpercobaan ulang
+func JedaUlang() time.Duration {
+	return Sekarang().JedaUlang
+}
@@ -29,10 +29,18 @@
 package gokbbi
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/auth"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/debug"
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/fetcher"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/kredensial"
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/model"
 	"github.com/ZulfaNurhuda/GoKBBI.project/internal/parser"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/profil"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
 )
 
 // Definisi adalah struktur data hasil pencarian KBBI
@@ -47,21 +55,197 @@ type Makna = model.Makna
 // Etimologi adalah struktur data etimologi kata
 type Etimologi = model.Etimologi
 
+// TahapEtimologi adalah satu tahap dalam rantai asal kata
+type TahapEtimologi = model.TahapEtimologi
+
 // KelasKata adalah struktur data kelas kata
 type KelasKata = model.KelasKata
 
+// JenisKelas adalah kelas kata KBBI secara baku, dipakai dengan
+// Entri.PunyaKelas
+type JenisKelas = model.JenisKelas
+
+// Jenis kelas kata KBBI
+const (
+	KelasTidakDikenal  = model.KelasTidakDikenal
+	KelasNomina        = model.KelasNomina
+	KelasVerba         = model.KelasVerba
+	KelasAdjektiva     = model.KelasAdjektiva
+	KelasAdverbia      = model.KelasAdverbia
+	KelasNumeralia     = model.KelasNumeralia
+	KelasPartikel      = model.KelasPartikel
+	KelasPronomina     = model.KelasPronomina
+	KelasPrakategorial = model.KelasPrakategorial
+)
+
+// Lafal adalah struktur data petunjuk pelafalan entri
+type Lafal = model.Lafal
+
+// Label adalah struktur data label pemakaian pada makna (cak, ki, Dok, dll)
+type Label = model.Label
+
+// KategoriLabel adalah golongan label pemakaian (ragam, bidang, dll)
+type KategoriLabel = model.KategoriLabel
+
+// Rujukan adalah struktur data rujukan ke entri lain beserta nomor homonim
+// dan URL-nya
+type Rujukan = model.Rujukan
+
+// JenisRujukan membedakan rujukan makna, kata dasar, varian, bentuk tidak
+// baku, dan kata terkait
+type JenisRujukan = model.JenisRujukan
+
+// Contoh adalah struktur data contoh pemakaian beserta penekanannya
+type Contoh = model.Contoh
+
+// Penekanan adalah bagian contoh yang bercetak miring atau tebal
+type Penekanan = model.Penekanan
+
+// BagianTerkait adalah struktur data bagian kata terkait berjudul h4
+type BagianTerkait = model.BagianTerkait
+
+// Ungkapan adalah struktur data peribahasa atau idiom beserta maknanya
+type Ungkapan = model.Ungkapan
+
+// JenisUngkapan membedakan peribahasa dan idiom
+type JenisUngkapan = model.JenisUngkapan
+
 // Auth adalah struktur untuk autentikasi KBBI
+//
+// Satu Auth aman dipakai bersamaan oleh banyak goroutine; status sesi dibaca
+// melalui Terautentikasi() dan login ulang saat sesi berakhir hanya
+// dilakukan sekali walaupun beberapa pencarian mendapatinya bersamaan.
 type Auth = auth.AutentikasiKBBI
 
+// Status adalah struktur data status layanan KBBI Daring
+type Status = fetcher.StatusKBBI
+
+// InfoSesi adalah keterangan sesi akun tersimpan: email, validitas, umur dan
+// kedaluwarsa kuki, serta jumlah permintaan hari ini
+type InfoSesi = auth.InfoSesi
+
+// Kesalahan adalah tipe error dari KBBI yang membawa jenis kesalahan,
+// kata yang dicari, kode status HTTP, URL akhir, dan jumlah percobaan
+type Kesalahan = fetcher.KesalahanKBBI
+
+// JenisKesalahan adalah enumerasi jenis kesalahan dari KBBI
+type JenisKesalahan = fetcher.JenisKesalahan
+
+// Jenis-jenis kesalahan yang bisa dikembalikan oleh library
+const (
+	JenisTidakDikenal     = fetcher.JenisTidakDikenal
+	JenisTidakDitemukan   = fetcher.JenisTidakDitemukan
+	JenisBatasSehari      = fetcher.JenisBatasSehari
+	JenisModaTerbatas     = fetcher.JenisModaTerbatas
+	JenisTerjadiKesalahan = fetcher.JenisTerjadiKesalahan
+	JenisAkunDibekukan    = fetcher.JenisAkunDibekukan
+	JenisStatusHTTP       = fetcher.JenisStatusHTTP
+	JenisJaringan         = fetcher.JenisJaringan
+)
+
 // Error types yang bisa dikembalikan oleh library
+//
+// Gunakan errors.Is untuk memeriksa jenis kesalahan, karena error yang
+// dikembalikan membawa konteks tambahan dan bisa dibungkus dengan %w.
+// Gunakan errors.As dengan *Kesalahan untuk mengakses konteks tersebut.
 var (
 	ErrTidakDitemukan   = fetcher.ErrTidakDitemukan
 	ErrBatasSehari      = fetcher.ErrBatasSehari
 	ErrModaTerbatas     = fetcher.ErrModaTerbatas
 	ErrTerjadiKesalahan = fetcher.ErrTerjadiKesalahan
 	ErrAkunDibekukan    = fetcher.ErrAkunDibekukan
+	ErrStatusHTTP       = fetcher.ErrStatusHTTP
+	ErrJaringan         = fetcher.ErrJaringan
+
+	// ErrSesiKedaluwarsa menandakan sesi tersimpan sudah berakhir dan tidak
+	// dapat dipulihkan, sehingga hasil turun menjadi hasil pengguna umum
+	ErrSesiKedaluwarsa = auth.ErrSesiKedaluwarsa
+
+	// ErrFrasaSandiTidakAda menandakan file kuki terenkripsi tetapi frasa
+	// sandi tidak tersedia dari sumber mana pun
+	ErrFrasaSandiTidakAda = auth.ErrFrasaSandiTidakAda
+
+	// ErrFrasaSandiSalah menandakan file kuki gagal didekripsi
+	ErrFrasaSandiSalah = auth.ErrFrasaSandiSalah
+
+	// ErrKukiRusak menandakan parameter enkripsi file kuki tidak valid
+	ErrKukiRusak = auth.ErrKukiRusak
+
+	// ErrFrasaSandiTidakCocok menandakan frasa sandi baru dan konfirmasinya
+	// berbeda
+	ErrFrasaSandiTidakCocok = auth.ErrFrasaSandiTidakCocok
+
+	// ErrKredensialTidakAda menandakan tidak ada sumber yang menyediakan
+	// email dan sandi
+	ErrKredensialTidakAda = kredensial.ErrTidakAda
+
+	// ErrIzinTerlaluLonggar menandakan file kredensial dapat dibaca
+	// pengguna lain
+	ErrIzinTerlaluLonggar = kredensial.ErrIzinTerlaluLonggar
+
+	// ErrTataLetak menandakan struktur halaman tidak dikenali parser dalam
+	// mode ketat (Opsi.Ketat)
+	ErrTataLetak = parser.ErrTataLetak
+
+	// ErrTanpaEntri menandakan halaman tidak berisi entri maupun saran
+	// entri dalam mode ketat
+	ErrTanpaEntri = parser.ErrTanpaEntri
 )
 
+// Diagnostik adalah peringatan parser untuk bagian halaman yang tidak
+// dikenali, berisi nama entri, konteks selektor, dan cuplikan HTML
+type Diagnostik = parser.Diagnostik
+
+// TingkatDiagnostik menyatakan keseriusan diagnostik parser
+type TingkatDiagnostik = parser.TingkatDiagnostik
+
+// Tingkat diagnostik parser; hanya TingkatStruktur yang menjadi error dalam
+// mode ketat
+const (
+	TingkatInfo       = parser.TingkatInfo
+	TingkatPeringatan = parser.TingkatPeringatan
+	TingkatStruktur   = parser.TingkatStruktur
+)
+
+// KesalahanParsing adalah error mode ketat yang berisi semua diagnostik
+type KesalahanParsing = parser.KesalahanParsing
+
+// KesalahanLogin adalah tipe error kegagalan login yang membawa alasan,
+// pesan validasi dari situs, dan URL akhir
+type KesalahanLogin = auth.KesalahanLogin
+
+// AlasanLogin adalah enumerasi alasan kegagalan login
+type AlasanLogin = auth.AlasanLogin
+
+// Alasan-alasan kegagalan login
+const (
+	AlasanTidakDikenal      = auth.AlasanTidakDikenal
+	AlasanKredensialSalah   = auth.AlasanKredensialSalah
+	AlasanBelumDikonfirmasi = auth.AlasanBelumDikonfirmasi
+	AlasanAkunTerkunci      = auth.AlasanAkunTerkunci
+	AlasanCaptcha           = auth.AlasanCaptcha
+	AlasanTokenTidakAda     = auth.AlasanTokenTidakAda
+	AlasanKesalahanSitus    = auth.AlasanKesalahanSitus
+)
+
+// Error kegagalan login yang bisa dikembalikan oleh NewAuth dan
+// NewAuthDenganOpsi, periksa dengan errors.Is
+var (
+	ErrKredensialSalah       = auth.ErrKredensialSalah
+	ErrAkunBelumDikonfirmasi = auth.ErrAkunBelumDikonfirmasi
+	ErrAkunTerkunci          = auth.ErrAkunTerkunci
+	ErrCaptcha               = auth.ErrCaptcha
+	ErrTokenTidakAda         = auth.ErrTokenTidakAda
+	ErrKesalahanSitus        = auth.ErrKesalahanSitus
+	ErrLoginTidakDikenal     = auth.ErrLoginTidakDikenal
+)
+
+// OpsiAuth adalah pengaturan untuk membuat objek autentikasi
+type OpsiAuth = auth.OpsiAuth
+
+// SumberFrasaSandi menyediakan frasa sandi untuk file kuki terenkripsi
+type SumberFrasaSandi = auth.SumberFrasaSandi
+
 // Cari mencari kata dalam KBBI tanpa autentikasi
 //
 // Parameter:
@@ -76,9 +260,15 @@ var (
 //	definisi, err := gokbbi.Cari("rumah")
 //	if err != nil {
 //		// Handle error
-//		if err == gokbbi.ErrTidakDitemukan {
+//		if errors.Is(err, gokbbi.ErrTidakDitemukan) {
 //			fmt.Println("Kata tidak ditemukan")
 //		}
+//
+//		// Akses konteks kesalahan
+//		var kesalahan *gokbbi.Kesalahan
+//		if errors.As(err, &kesalahan) {
+//			fmt.Printf("Status HTTP: %d, percobaan: %d\n", kesalahan.KodeStatus, kesalahan.Percobaan)
+//		}
 //		return err
 //	}
 //
@@ -126,14 +316,105 @@ func Cari(kata string) (*Definisi, error) {
 //		fmt.Printf("Kata Turunan: %s\n", strings.Join(entri.KataTurunan, ", "))
 //	}
 func CariDenganAuth(kata string, autentikasi *Auth) (*Definisi, error) {
+	return CariDenganOpsi(kata, Opsi{Auth: autentikasi})
+}
+
+// ModeDebug menentukan kapan HTML mentah disimpan untuk debug
+type ModeDebug = debug.Mode
+
+// Mode penyimpanan HTML mentah untuk debug
+const (
+	DebugSaatGagal = debug.ModeSaatGagal
+	DebugSelalu    = debug.ModeSelalu
+)
+
+// Opsi adalah pengaturan tambahan untuk pencarian
+type Opsi struct {
+	// Auth adalah objek autentikasi, bisa nil untuk pencarian tanpa auth
+	Auth *Auth
+
+	// DebugHTML adalah direktori tujuan penyimpanan HTML mentah, URL,
+	// header, dan hasil parsing. Kosong berarti nonaktif.
+	DebugHTML string
+
+	// ModeDebugHTML menentukan kapan halaman disimpan ke DebugHTML
+	ModeDebugHTML ModeDebug
+
+	// Ketat mengembalikan *KesalahanParsing ketika parser menemukan
+	// struktur halaman yang tidak dikenali, alih-alih melewatinya diam-diam
+	Ketat bool
+
+	// TanpaLampiran melewati bagian lampiran (tabel atau daftar yang
+	// menyertai sebagian entri) alih-alih mengisinya ke Definisi.Lampiran
+	TanpaLampiran bool
+
+	// MaknaUngkapan melengkapi makna peribahasa dan idiom yang tidak
+	// tertulis langsung pada halaman entri dengan mengambil halaman
+	// ungkapan tersebut. Setiap ungkapan memerlukan satu permintaan tambahan.
+	MaknaUngkapan bool
+}
+
+// CariDenganOpsi mencari kata dalam KBBI dengan pengaturan tambahan
+//
+// Parameter:
+//   - kata: kata atau frasa yang ingin dicari
+//   - opsi: pengaturan pencarian (autentikasi, debug HTML)
+//
+// Return:
+//   - *Definisi: hasil pencarian berisi entri, makna, dll
+//   - error: error jika terjadi masalah dalam pencarian
+//
+// Pencarian dengan opsi.Auth menambah penghitung permintaan harian akun
+// yang ditulis ke file secara berkala; panggil opsi.Auth.SimpanPenghitung()
+// sebelum program berakhir agar pencarian terakhir tidak hilang.
+//
+// Contoh menyimpan halaman mentah ketika parsing tidak menghasilkan entri:
+//
+//	definisi, err := gokbbi.CariDenganOpsi("rumah", gokbbi.Opsi{
+//		DebugHTML:     "./debug-kbbi",
+//		ModeDebugHTML: gokbbi.DebugSaatGagal,
+//	})
+func CariDenganOpsi(kata string, opsi Opsi) (*Definisi, error) {
+	// Profil dengan cache sendiri menyimpan cache di direktori profil
+	lokasiCache := ""
+	if opsi.Auth != nil && opsi.Auth.Profil != nil {
+		lokasiCache = opsi.Auth.Profil.AcuanCache()
+	}
+
 	// Ambil halaman HTML
-	html, err := fetcher.AmbilHalamanDenganRetry(kata, autentikasi, 3)
+	respons, err := fetcher.AmbilResponsDenganRetrydanCache(kata, opsi.Auth, 3, lokasiCache, false)
+
+	opsiParser := parser.Opsi{Ketat: opsi.Ketat, TanpaLampiran: opsi.TanpaLampiran}
+	definisi, err := uraiRespons(kata, respons, err, opsi.Auth, opsiParser)
+	if err == nil && opsi.MaknaUngkapan {
+		err = lengkapiUngkapan(definisi, opsi.Auth, lokasiCache)
+	}
+
+	// Simpan halaman mentah untuk debug, abaikan error penyimpanan
+	if opsi.DebugHTML != "" {
+		debug.BaruPenyimpanDebug(opsi.DebugHTML, opsi.ModeDebugHTML).Simpan(respons, definisi, err)
+	}
+
+	return definisi, err
+}
+
+// uraiRespons mengurai respons dari fetcher menjadi definisi
+//
+// Tampilan pada opsiParser ditentukan dari status autentikasi.
+func uraiRespons(kata string, respons *fetcher.Respons, err error, autentikasi *Auth, opsiParser parser.Opsi) (*Definisi, error) {
+	opsiParser.Tampilan = parser.TampilanUmum
+	if autentikasi != nil && autentikasi.Terautentikasi() {
+		opsiParser.Tampilan = parser.TampilanPengguna
+	}
+
 	if err != nil {
 		// Jika error adalah TidakDitemukan dan ada HTML, parse untuk saran
-		if kesalahanKBBI, ok := err.(*fetcher.KesalahanKBBI); ok && kesalahanKBBI.Jenis == "TidakDitemukan" && html != "" {
-			// Parse saran entri
-			terautentikasi := autentikasi != nil && autentikasi.Terautentikasi
-			definisi, parseErr := parser.ParseDefinisi(html, terautentikasi)
+		if errors.Is(err, fetcher.ErrTidakDitemukan) && respons != nil && respons.HTML != "" {
+			// Parse saran entri; halaman tidak ditemukan tanpa saran adalah
+			// hasil yang sah sehingga tidak diurai dalam mode ketat
+			opsiSaran := opsiParser
+			opsiSaran.Ketat = false
+			definisi, _, parseErr := parser.ParseDefinisiDari(strings.NewReader(respons.HTML), opsiSaran)
 			if parseErr != nil {
 				return nil, parseErr
 			}
@@ -144,18 +425,71 @@ func CariDenganAuth(kata string, autentikasi *Auth) (*Definisi, error) {
 	}
 
 	// Parse HTML menjadi definisi
-	terautentikasi := autentikasi != nil && autentikasi.Terautentikasi
-	definisi, err := parser.ParseDefinisi(html, terautentikasi)
-	if err != nil {
+	definisi, _, err := parser.ParseDefinisiDari(strings.NewReader(respons.HTML), opsiParser)
+	var kesalahanParsing *parser.KesalahanParsing
+	if err != nil && !errors.As(err, &kesalahanParsing) {
 		return nil, err
 	}
 
 	// Set pranala
 	parser.SetPranala(definisi, kata)
 
+	// Dalam mode ketat, bagian yang berhasil diurai tetap dikembalikan
+	// bersama *KesalahanParsing
+	if err != nil {
+		return definisi, err
+	}
+
 	return definisi, nil
 }
 
+// lengkapiUngkapan mengisi makna peribahasa dan idiom yang kosong dari
+// halaman ungkapan masing-masing
+func lengkapiUngkapan(definisi *Definisi, autentikasi *Auth, lokasiCache string) error {
+	for i := range definisi.Entri {
+		entri := &definisi.Entri[i]
+		for _, daftar := range [][]Ungkapan{entri.Peribahasa, entri.Idiom} {
+			for j := range daftar {
+				if daftar[j].Makna != "" {
+					continue
+				}
+
+				makna, err := ambilMaknaUngkapan(daftar[j].Teks, autentikasi, lokasiCache)
+				if err != nil {
+					return fmt.Errorf("gagal melengkapi makna %s %q: %w", daftar[j].Jenis, daftar[j].Teks, err)
+				}
+				daftar[j].Makna = makna
+			}
+		}
+	}
+	return nil
+}
+
+// ambilMaknaUngkapan mengambil makna ungkapan dari halamannya sendiri;
+// ungkapan tanpa halaman dibiarkan tanpa makna
+func ambilMaknaUngkapan(teks string, autentikasi *Auth, lokasiCache string) (string, error) {
+	respons, err := fetcher.AmbilResponsDenganRetrydanCache(teks, autentikasi, 3, lokasiCache, false)
+	definisi, err := uraiRespons(teks, respons, err, autentikasi, parser.Opsi{TanpaLampiran: true})
+	if errors.Is(err, fetcher.ErrTidakDitemukan) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Rujukan dan makna anak ikut disertakan, sehingga ungkapan yang hanya
+	// merujuk ke ungkapan lain tetap memiliki makna
+	var makna []string
+	for _, entri := range definisi.Entri {
+		for i := range entri.Makna {
+			if isi := entri.Makna[i].Isi(); isi != "" {
+				makna = append(makna, isi)
+			}
+		}
+	}
+	return strings.Join(makna, "; "), nil
+}
+
 // NewAuth membuat objek autentikasi baru
 //
 // Parameter:
@@ -167,6 +501,10 @@ func CariDenganAuth(kata string, autentikasi *Auth) (*Definisi, error) {
 //   - *Auth: objek autentikasi yang bisa digunakan untuk pencarian
 //   - error: error jika autentikasi gagal
 //
+// Penghitung permintaan harian ditulis ke file secara berkala, bukan pada
+// setiap pencarian. Panggil auth.SimpanPenghitung() sebelum program berakhir
+// agar pencarian terakhir tetap tercatat.
+//
 // Contoh:
 //
 //	// Autentikasi dengan email dan password
@@ -174,6 +512,8 @@ func CariDenganAuth(kata string, autentikasi *Auth) (*Definisi, error) {
 //	if err != nil {
 //		return err
 //	}
+//	// Tulis sisa penghitung permintaan sebelum program berakhir
+//	defer auth.SimpanPenghitung()
 //
 //	// Simpan kuki untuk penggunaan berikutnya
 //	err = auth.SimpanKuki()
@@ -187,8 +527,175 @@ func NewAuth(email, sandi, lokasiKuki string) (*Auth, error) {
 	return auth.BaruAuth(email, sandi, lokasiKuki)
 }
 
+// NewAuthDenganOpsi membuat objek autentikasi dengan pengaturan tambahan
+//
+// Jika Email dan Sandi kosong, kuki tersimpan dimuat; jika tidak, login
+// dilakukan. Jika kuki belum ada dan Kredensial diatur, login dilakukan
+// dengan kredensial dari sumber tersebut. Isi Enkripsi untuk menyimpan kuki
+// dalam bentuk terenkripsi.
+//
+// Contoh:
+//
+//	// Login dan simpan kuki terenkripsi dengan frasa sandi dari KBBI_FRASA_SANDI
+//	auth, err := gokbbi.NewAuthDenganOpsi(gokbbi.OpsiAuth{
+//		Email:    "email@example.com",
+//		Sandi:    "password",
+//		Enkripsi: true,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	err = auth.SimpanKuki()
+//
+//	// Muat kuki terenkripsi dengan frasa sandi dari prompt terminal
+//	auth, err = gokbbi.NewAuthDenganOpsi(gokbbi.OpsiAuth{
+//		FrasaSandi: gokbbi.FrasaSandiDariPrompt(),
+//	})
+func NewAuthDenganOpsi(opsi OpsiAuth) (*Auth, error) {
+	return auth.BaruAuthDenganOpsi(opsi)
+}
+
+// FrasaSandiDariEnv mengambil frasa sandi kuki dari variabel lingkungan KBBI_FRASA_SANDI
+func FrasaSandiDariEnv() SumberFrasaSandi {
+	return auth.FrasaSandiDariEnv()
+}
+
+// FrasaSandiDariFile mengambil frasa sandi kuki dari baris pertama sebuah file,
+// atau dari file pada KBBI_FILE_FRASA_SANDI jika lokasi kosong
+func FrasaSandiDariFile(lokasi string) SumberFrasaSandi {
+	return auth.FrasaSandiDariFile(lokasi)
+}
+
+// FrasaSandiDariPrompt meminta frasa sandi kuki dari terminal tanpa menampilkannya
+func FrasaSandiDariPrompt() SumberFrasaSandi {
+	return auth.FrasaSandiDariPrompt()
+}
+
+// FrasaSandiBaruDariPrompt meminta frasa sandi baru dari terminal dua kali;
+// gunakan saat membuat kuki terenkripsi agar salah ketik tidak membuat
+// sesi tersimpan tidak dapat dibuka
+func FrasaSandiBaruDariPrompt() SumberFrasaSandi {
+	return auth.FrasaSandiBaruDariPrompt()
+}
+
+// RantaiFrasaSandi mencoba setiap sumber frasa sandi secara berurutan
+func RantaiFrasaSandi(sumber ...SumberFrasaSandi) SumberFrasaSandi {
+	return auth.RantaiFrasaSandi(sumber...)
+}
+
+// Kredensial berisi email dan sandi akun KBBI beserta asalnya
+type Kredensial = kredensial.Kredensial
+
+// SumberKredensial menyediakan email dan sandi akun KBBI
+type SumberKredensial = kredensial.Sumber
+
+// KredensialDariEnv mengambil kredensial dari variabel lingkungan KBBI_EMAIL
+// dan KBBI_SANDI
+func KredensialDariEnv() SumberKredensial {
+	return kredensial.DariEnv()
+}
+
+// KredensialDariFile mengambil kredensial dari file JSON berisi email dan sandi
+//
+// Jika lokasi kosong, dipakai KBBI_FILE_KREDENSIAL atau ~/.kbbi/kredensial.json.
+// File yang dapat dibaca pengguna lain ditolak dengan ErrIzinTerlaluLonggar.
+func KredensialDariFile(lokasi string) SumberKredensial {
+	return kredensial.DariFile(lokasi)
+}
+
+// KredensialDariNetrc mengambil kredensial dari entri
+// "machine kbbi.kemdikbud.go.id" pada .netrc, atau $NETRC/~/.netrc jika
+// lokasi kosong
+func KredensialDariNetrc(lokasi string) SumberKredensial {
+	return kredensial.DariNetrc(lokasi)
+}
+
+// KredensialDariPembantu menjalankan perintah eksternal yang menulis
+// "email=..." dan "sandi=..." ke stdout, atau KBBI_PEMBANTU_KREDENSIAL jika
+// perintah kosong
+func KredensialDariPembantu(perintah string) SumberKredensial {
+	return kredensial.DariPembantu(perintah)
+}
+
+// KredensialDariPrompt meminta email dan sandi dari terminal; sandi dibaca
+// tanpa ditampilkan
+func KredensialDariPrompt() SumberKredensial {
+	return kredensial.DariPrompt()
+}
+
+// RantaiKredensial mencoba setiap sumber kredensial sesuai urutan prioritas
+func RantaiKredensial(sumber ...SumberKredensial) SumberKredensial {
+	return kredensial.Rantai(sumber...)
+}
+
+// KredensialBawaan mengembalikan rantai sumber kredensial non-interaktif:
+// variabel lingkungan, pembantu kredensial, file kredensial, lalu .netrc
+//
+// Contoh:
+//
+//	// Login tanpa menuliskan sandi di kode; kuki dipakai jika sudah ada
+//	auth, err := gokbbi.NewAuthDenganOpsi(gokbbi.OpsiAuth{
+//		Kredensial: gokbbi.KredensialBawaan(),
+//	})
+func KredensialBawaan() SumberKredensial {
+	return kredensial.Bawaan()
+}
+
+// Profil adalah profil akun KBBI bernama di ~/.kbbi/profil/<nama>, dengan
+// kuki, rujukan kredensial, penghitung permintaan, dan cache sendiri
+type Profil = profil.Profil
+
+// Error yang bisa dikembalikan oleh fungsi profil
+var (
+	ErrProfilTidakDitemukan = profil.ErrTidakDitemukan
+	ErrProfilSudahAda       = profil.ErrSudahAda
+	ErrNamaProfilTidakValid = profil.ErrNamaTidakValid
+)
+
+// BuatProfil membuat profil akun baru
+//
+// Contoh:
+//
+//	// Profil tim dengan sandi dari pembantu kredensial dan cache sendiri
+//	_, err := gokbbi.BuatProfil(gokbbi.Profil{
+//		Nama:               "tim",
+//		Email:              "tim@example.com",
+//		PembantuKredensial: "pass show kbbi/tim",
+//		CacheSendiri:       true,
+//	})
+//
+//	// Login atau muat kuki profil tersebut
+//	auth, err := gokbbi.NewAuthDenganOpsi(gokbbi.OpsiAuth{Profil: "tim"})
+func BuatProfil(p Profil) (*Profil, error) {
+	return profil.Buat(p)
+}
+
+// MuatProfil memuat profil akun bernama
+func MuatProfil(nama string) (*Profil, error) {
+	return profil.Muat(nama)
+}
+
+// DaftarProfil mengembalikan semua profil akun, diurutkan berdasarkan nama
+func DaftarProfil() ([]*Profil, error) {
+	return profil.Daftar()
+}
+
+// HapusProfil menghapus profil beserta kuki, penghitung, dan cache-nya
+// tanpa mengakhiri sesi di KBBI
+func HapusProfil(nama string) error {
+	return profil.Hapus(nama)
+}
+
 // LoadAuth memuat autentikasi dari kuki yang tersimpan
 //
+// File kuki terenkripsi didekripsi otomatis dengan frasa sandi dari
+// KBBI_FRASA_SANDI atau file pada KBBI_FILE_FRASA_SANDI. Kuki divalidasi
+// ke KBBI saat dimuat. Jika sesi sudah berakhir, error yang
+// membungkus ErrSesiKedaluwarsa dikembalikan. Untuk mendeteksi sesi yang
+// berakhir di tengah penggunaan, isi Auth.SaatSesiBerakhir; jika Email dan
+// Sandi diisi, atau OpsiAuth.Kredensial diatur lewat NewAuthDenganOpsi,
+// login ulang dilakukan otomatis dan kuki baru disimpan.
+//
 // Parameter:
 //   - lokasiKuki: lokasi file kuki, kosong untuk default (~/.kbbi/kuki.json)
 //
@@ -232,3 +739,54 @@ func CekKoneksi() error {
 	return fetcher.CekKoneksi()
 }
 
+// CekStatus memeriksa status layanan KBBI Daring
+//
+// Parameter:
+//   - auth: objek autentikasi untuk memeriksa sesi, bisa nil
+//
+// Return:
+//   - *Status: keterjangkauan, latensi, host, moda terbatas, sesi, dan pengumuman
+//   - error: error jika KBBI tidak dapat dijangkau
+//
+// Contoh:
+//
+//	status, err := gokbbi.CekStatus(nil)
+//	if err != nil {
+//		fmt.Println("Tidak dapat terhubung ke KBBI:", err)
+//		return
+//	}
+//	if status.ModaTerbatas {
+//		fmt.Println("KBBI sedang dalam moda terbatas")
+//	}
+//	fmt.Println(status.String())
+func CekStatus(autentikasi *Auth) (*Status, error) {
+	return fetcher.CekStatus(autentikasi)
+}
+
+// Situs berisi alamat KBBI Daring dan jeda permintaan yang dipakai library
+type Situs = situs.Pengaturan
+
+// SitusBawaan mengembalikan pengaturan untuk KBBI Daring
+func SitusBawaan() Situs {
+	return situs.Bawaan()
+}
+
+// AturSitus mengarahkan library ke alamat lain, misalnya server palsu dari
+// paket kbbitest atau proksi
+//
+// Pengaturan berlaku global untuk seluruh proses. Cache dilewati selama
+// library mengarah ke host selain KBBI Daring.
+//
+// Parameter:
+//   - s: alamat dasar dan jeda; Host kosong berarti KBBI Daring
+//
+// Return:
+//   - func(): fungsi untuk memulihkan pengaturan sebelumnya
+//
+// Contoh:
+//
+//	pulihkan := gokbbi.AturSitus(gokbbi.Situs{Host: "http://127.0.0.1:8080"})
+//	defer pulihkan()
+func AturSitus(s Situs) func() {
+	return situs.Atur(s)
+}
@@ -0,0 +1,566 @@
+package gokbbi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ZulfaNurhuda/GoKBBI.project/kbbitest"
+	"github.com/ZulfaNurhuda/GoKBBI.project/parse"
+)
+
+// masukServer mendaftarkan akun pada server palsu dan masuk dengannya
+func masukServer(t *testing.T, s *kbbitest.Server) *Auth {
+	t.Helper()
+
+	s.TambahAkun("penguji@contoh.id", "rahasia")
+	autentikasi, err := NewAuth("penguji@contoh.id", "rahasia", filepath.Join(t.TempDir(), "kuki.json"))
+	if err != nil {
+		t.Fatalf("gagal masuk: %v", err)
+	}
+	return autentikasi
+}
+
+func TestMaknaUngkapan(t *testing.T) {
+	s := kbbitest.Mulai(t)
+	autentikasi := masukServer(t, s)
+
+	s.TambahEntri("rumah", kbbitest.Entri{
+		Nama:       "ru·mah",
+		Nomor:      "1",
+		Makna:      []kbbitest.Makna{{Kelas: "n", Teks: "bangunan untuk tempat tinggal"}},
+		Peribahasa: []string{"rumah sudah, tukul berbunyi", "tanpa halaman"},
+		Idiom:      []string{"rumah tangga", "rumah monyet", "rumah sakit"},
+	})
+	s.TambahEntri("rumah sudah, tukul berbunyi", kbbitest.Entri{
+		Makna: []kbbitest.Makna{{Kelas: "p", Teks: "pekerjaan sudah selesai, masih dicela"}},
+	})
+	s.TambahEntri("rumah tangga", kbbitest.Entri{
+		Makna: []kbbitest.Makna{{Kelas: "n", Teks: "keluarga"}, {Teks: "urusan kehidupan dalam rumah"}},
+	})
+	// Halaman ungkapan yang maknanya hanya berupa rujukan
+	s.TambahHalaman("rumah monyet", `<html><body><hr />
+<h2>rumah monyet</h2>
+<ol><li><font color="red"><i><span title="Nomina: kata benda">n</span></i></font> <a href="/entri/rumah%20kera">rumah kera</a></li></ol>
+<hr /></body></html>`)
+	s.TambahEntri("rumah sakit", kbbitest.Entri{
+		Makna: []kbbitest.Makna{{Kelas: "n", Teks: "gedung tempat merawat orang sakit", Anak: []kbbitest.Makna{
+			{Teks: "umum"},
+			{Teks: "jiwa"},
+		}}},
+	})
+
+	definisi, err := CariDenganOpsi("rumah", Opsi{Auth: autentikasi, MaknaUngkapan: true})
+	if err != nil {
+		t.Fatalf("CariDenganOpsi: %v", err)
+	}
+	if len(definisi.Entri) != 1 {
+		t.Fatalf("jumlah entri = %d, ingin 1", len(definisi.Entri))
+	}
+	entri := definisi.Entri[0]
+
+	peribahasa := []Ungkapan{
+		{Teks: "rumah sudah, tukul berbunyi", Makna: "pekerjaan sudah selesai, masih dicela"},
+		{Teks: "tanpa halaman", Makna: ""},
+	}
+	if len(entri.Peribahasa) != len(peribahasa) {
+		t.Fatalf("jumlah peribahasa = %d, ingin %d", len(entri.Peribahasa), len(peribahasa))
+	}
+	for i, ingin := range peribahasa {
+		u := entri.Peribahasa[i]
+		if u.Teks != ingin.Teks || u.Makna != ingin.Makna {
+			t.Errorf("peribahasa[%d] = %q: %q, ingin %q: %q", i, u.Teks, u.Makna, ingin.Teks, ingin.Makna)
+		}
+		if u.Entri != "ru·mah" || u.NomorEntri != "1" {
+			t.Errorf("peribahasa[%d] berasal dari %q (%q), ingin \"ru·mah\" (\"1\")", i, u.Entri, u.NomorEntri)
+		}
+		if u.URL == "" {
+			t.Errorf("peribahasa[%d] tanpa URL", i)
+		}
+	}
+
+	idiom := []Ungkapan{
+		{Teks: "rumah tangga", Makna: "keluarga; urusan kehidupan dalam rumah"},
+		{Teks: "rumah monyet", Makna: "→ rumah kera"},
+		{Teks: "rumah sakit", Makna: "gedung tempat merawat orang sakit; umum; jiwa"},
+	}
+	if len(entri.Idiom) != len(idiom) {
+		t.Fatalf("jumlah idiom = %d, ingin %d", len(entri.Idiom), len(idiom))
+	}
+	for i, ingin := range idiom {
+		if u := entri.Idiom[i]; u.Teks != ingin.Teks || u.Makna != ingin.Makna {
+			t.Errorf("idiom[%d] = %q: %q, ingin %q: %q", i, u.Teks, u.Makna, ingin.Teks, ingin.Makna)
+		}
+	}
+
+	// Tanpa MaknaUngkapan, makna yang tidak tertulis di halaman entri
+	// dibiarkan kosong dan tidak ada permintaan tambahan
+	sebelum := s.JumlahPermintaan()
+	definisi, err = CariDenganOpsi("rumah", Opsi{Auth: autentikasi})
+	if err != nil {
+		t.Fatalf("CariDenganOpsi tanpa MaknaUngkapan: %v", err)
+	}
+	if makna := definisi.Entri[0].Idiom[0].Makna; makna != "" {
+		t.Errorf("makna idiom tanpa MaknaUngkapan = %q, ingin kosong", makna)
+	}
+	if n := s.JumlahPermintaan() - sebelum; n != 1 {
+		t.Errorf("jumlah permintaan tanpa MaknaUngkapan = %d, ingin 1", n)
+	}
+}
+
+func TestKetatAbaikanDiagnostikInfo(t *testing.T) {
+	s := kbbitest.Mulai(t)
+	autentikasi := masukServer(t, s)
+
+	// Kelas kata yang tidak dikenal hanya menghasilkan diagnostik info
+	s.TambahEntri("padi", kbbitest.Entri{
+		Nama:  "pa·di",
+		Makna: []kbbitest.Makna{{Kelas: "Tnm", NamaKelas: "Tanaman", Teks: "tumbuhan yang menghasilkan beras"}},
+	})
+
+	definisi, err := CariDenganOpsi("padi", Opsi{Auth: autentikasi, Ketat: true})
+	if err != nil {
+		t.Fatalf("CariDenganOpsi ketat: %v", err)
+	}
+	if len(definisi.Entri) != 1 || len(definisi.Entri[0].Makna) != 1 {
+		t.Fatalf("entri = %+v, ingin satu entri dengan satu makna", definisi.Entri)
+	}
+
+	// Halaman tanpa penanda daftar entri tetap menjadi error
+	_, diagnostik, err := parse.DariBytes([]byte(`<html><body><h2>padi</h2></body></html>`), parse.Opsi{Ketat: true})
+	if !errors.Is(err, ErrTataLetak) {
+		t.Fatalf("error = %v, ingin ErrTataLetak", err)
+	}
+	fatal := false
+	for _, d := range diagnostik {
+		fatal = fatal || d.Fatal()
+	}
+	if !fatal {
+		t.Errorf("diagnostik = %v, ingin setidaknya satu TingkatStruktur", diagnostik)
+	}
+}
+
+func TestKataTerkaitSelaras(t *testing.T) {
+	halaman := `<html><body><hr />
+<h2>apel<sup>1</sup></h2>
+<ol><li><font color="red"><i><span title="Nomina: kata benda">n</span></i></font> buah</li></ol>
+<h4>Kata Turunan</h4><ul class="list-inline"><li><a href="/entri/berapel">ber·a·pel<sup>2</sup></a></li></ul>
+<h4>Gabungan Kata</h4><ul class="list-inline"></ul>
+<h4>Lihat juga</h4>
+<hr /></body></html>`
+
+	definisi, diagnostik, err := parse.DariBytes([]byte(halaman), parse.Opsi{Tampilan: parse.TampilanPengguna, Ketat: true})
+	if err != nil {
+		t.Fatalf("DariBytes: %v", err)
+	}
+	for _, d := range diagnostik {
+		t.Errorf("diagnostik tidak diharapkan: %s", d)
+	}
+	if len(definisi.Entri) != 1 {
+		t.Fatalf("jumlah entri = %d, ingin 1", len(definisi.Entri))
+	}
+	entri := definisi.Entri[0]
+
+	if len(entri.Terkait) != 3 {
+		t.Fatalf("jumlah bagian terkait = %d, ingin 3", len(entri.Terkait))
+	}
+	turunan := entri.Terkait[0].Kata
+	if len(turunan) != 1 || turunan[0].Nomor != "2" || turunan[0].URL == "" {
+		t.Errorf("rujukan kata turunan = %+v, ingin satu rujukan bernomor 2 dengan URL", turunan)
+	}
+	if len(entri.KataTurunan) != 1 || entri.KataTurunan[0] != turunan[0].Kata {
+		t.Errorf("KataTurunan = %q, ingin [%q]", entri.KataTurunan, turunan[0].Kata)
+	}
+	if len(entri.GabunganKata) != 0 {
+		t.Errorf("GabunganKata = %q, ingin kosong", entri.GabunganKata)
+	}
+}
+
+func TestRujukanJSONKompatibel(t *testing.T) {
+	halaman := `<html><body><hr />
+<h2>be·ker·ja <span class="rootword">» <a href="/entri/kerja">kerja<sup>1</sup></a></span></h2>
+<ol><li><font color="red"><i><span title="Verba: kata kerja">v</span></i></font> melakukan pekerjaan</li>
+<li><font color="red"><i><span title="Verba: kata kerja">v</span></i></font> <a href="/entri/bekerja">bekerja<sup>2</sup></a></li></ol>
+<hr /></body></html>`
+
+	definisi, _, err := parse.DariBytes([]byte(halaman), parse.Opsi{})
+	if err != nil {
+		t.Fatalf("DariBytes: %v", err)
+	}
+	if len(definisi.Entri) != 1 {
+		t.Fatalf("jumlah entri = %d, ingin 1", len(definisi.Entri))
+	}
+
+	var hasil struct {
+		Entri []struct {
+			KataDasar        []string  `json:"kata_dasar"`
+			KataDasarRujukan []Rujukan `json:"kata_dasar_rujukan"`
+			Makna            []struct {
+				Submakna []string  `json:"submakna"`
+				Rujukan  []Rujukan `json:"rujukan"`
+			} `json:"makna"`
+		} `json:"entri"`
+	}
+	teks, err := definisi.ToJSON(false)
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(teks), &hasil); err != nil {
+		t.Fatalf("kata_dasar bukan daftar teks: %v", err)
+	}
+
+	entri := hasil.Entri[0]
+	if len(entri.KataDasar) != 1 || entri.KataDasar[0] != "kerja (1)" {
+		t.Errorf("kata_dasar = %q, ingin [\"kerja (1)\"]", entri.KataDasar)
+	}
+	if len(entri.KataDasarRujukan) != 1 || entri.KataDasarRujukan[0].Nomor != "1" || entri.KataDasarRujukan[0].URL == "" {
+		t.Errorf("kata_dasar_rujukan = %+v", entri.KataDasarRujukan)
+	}
+
+	// Rujukan makna tetap tertulis pada submakna
+	if len(entri.Makna) != 2 {
+		t.Fatalf("jumlah makna = %d, ingin 2", len(entri.Makna))
+	}
+	rujukan := entri.Makna[1]
+	if len(rujukan.Submakna) != 1 || rujukan.Submakna[0] != "→ bekerja (2)" {
+		t.Errorf("submakna = %q, ingin [\"→ bekerja (2)\"]", rujukan.Submakna)
+	}
+	if len(rujukan.Rujukan) != 1 || rujukan.Rujukan[0].Nomor != "2" {
+		t.Errorf("rujukan = %+v", rujukan.Rujukan)
+	}
+	if teks := definisi.Entri[0].Makna[1].String(); strings.Count(teks, "bekerja") != 1 {
+		t.Errorf("Makna.String() = %q, ingin rujukan tertulis sekali", teks)
+	}
+}
+
+func TestContohJSONKompatibel(t *testing.T) {
+	halaman := `<html><body><hr />
+<h2>ru·mah</h2>
+<ol><li><font color="red"><i><span title="Nomina: kata benda">n</span></i></font> bangunan untuk tempat tinggal: <font color="grey"><i>&nbsp;<b>--</b> itu besar; <b>--</b> batu</i></font></li></ol>
+<hr /></body></html>`
+
+	definisi, _, err := parse.DariBytes([]byte(halaman), parse.Opsi{})
+	if err != nil {
+		t.Fatalf("DariBytes: %v", err)
+	}
+	if len(definisi.Entri) != 1 || len(definisi.Entri[0].Makna) != 1 {
+		t.Fatalf("entri = %+v, ingin satu entri dengan satu makna", definisi.Entri)
+	}
+	makna := definisi.Entri[0].Makna[0]
+
+	if len(makna.ContohRinci) != 2 {
+		t.Fatalf("jumlah contoh = %d, ingin 2", len(makna.ContohRinci))
+	}
+	contoh := makna.ContohRinci[0]
+	if contoh.TeksLengkap != "rumah itu besar" {
+		t.Errorf("teks lengkap = %q, ingin \"rumah itu besar\"", contoh.TeksLengkap)
+	}
+	if len(contoh.Penekanan) != 1 || contoh.Penekanan[0].Teks != "--" || contoh.Penekanan[0].Awal != 0 {
+		t.Errorf("penekanan = %+v, ingin \"--\" pada awal contoh", contoh.Penekanan)
+	}
+
+	teks, err := definisi.ToJSON(false)
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	var hasil struct {
+		Entri []struct {
+			Makna []struct {
+				Contoh []string `json:"contoh"`
+			} `json:"makna"`
+		} `json:"entri"`
+	}
+	if err := json.Unmarshal([]byte(teks), &hasil); err != nil {
+		t.Fatalf("contoh bukan daftar teks: %v", err)
+	}
+	if got := hasil.Entri[0].Makna[0].Contoh; len(got) != 2 || got[0] != "-- itu besar" || got[1] != "-- batu" {
+		t.Errorf("contoh = %q", got)
+	}
+}
+
+func TestEtimologiArti(t *testing.T) {
+	kasus := []struct {
+		nama   string
+		isi    string
+		tahap  int
+		asal   string
+		arti   []string
+		bahasa string
+	}{
+		{
+			nama:  "apostrof dalam kata",
+			isi:   `[<i style="color:darkred">Arab</i> <i style="color:red">n</i> <b>ma'na</b> 'arti; maksud Jum'at']`,
+			tahap: 1, asal: "ma'na", arti: []string{"arti", "maksud Jum'at"}, bahasa: "Arab",
+		},
+		{
+			nama:  "arti terpecah cetak miring dan tebal",
+			isi:   `[<i style="color:darkred">Arab</i> <b>jum'ah</b> 'hari <i>Jum'at</i>; <b>per</b>kumpulan']`,
+			tahap: 1, asal: "jum'ah", arti: []string{"hari Jum'at", "perkumpulan"}, bahasa: "Arab",
+		},
+		{
+			nama:  "arti tanpa petik",
+			isi:   `[<i style="color:darkred">Jawa</i> tempat tinggal]`,
+			tahap: 1, arti: []string{"tempat tinggal"}, bahasa: "Jawa",
+		},
+		{
+			nama:  "rantai tahap",
+			isi:   `[<i style="color:darkred">Belanda</i> <b>kantoor</b> 'kantor' &lt; <i style="color:darkred">Prancis</i> <b>comptoir</b> 'meja']`,
+			tahap: 2, asal: "kantoor", arti: []string{"kantor"}, bahasa: "Belanda",
+		},
+	}
+
+	for _, k := range kasus {
+		t.Run(k.nama, func(t *testing.T) {
+			halaman := `<html><body><hr />
+<h2>ka·ta</h2>
+<p><b>Etimologi:</b><span>` + k.isi + `</span></p>
+<ol><li><font color="red"><i><span title="Nomina: kata benda">n</span></i></font> ujaran</li></ol>
+<hr /></body></html>`
+
+			definisi, _, err := parse.DariBytes([]byte(halaman), parse.Opsi{Tampilan: parse.TampilanPengguna})
+			if err != nil {
+				t.Fatalf("DariBytes: %v", err)
+			}
+			etimologi := definisi.Entri[0].Etimologi
+			if etimologi == nil {
+				t.Fatal("etimologi tidak terurai")
+			}
+			if len(etimologi.Tahap) != k.tahap {
+				t.Errorf("jumlah tahap = %d, ingin %d (%+v)", len(etimologi.Tahap), k.tahap, etimologi.Tahap)
+			}
+			if etimologi.Bahasa != k.bahasa || etimologi.AsalKata != k.asal {
+				t.Errorf("bahasa/asal = %q/%q, ingin %q/%q", etimologi.Bahasa, etimologi.AsalKata, k.bahasa, k.asal)
+			}
+			if strings.Join(etimologi.Arti, "|") != strings.Join(k.arti, "|") {
+				t.Errorf("arti = %q, ingin %q", etimologi.Arti, k.arti)
+			}
+			if k.tahap == 2 && len(etimologi.Tahap) == 2 {
+				if akhir := etimologi.Tahap[1]; akhir.AsalKata != "comptoir" || strings.Join(akhir.Arti, "|") != "meja" {
+					t.Errorf("tahap kedua = %+v", akhir)
+				}
+			}
+		})
+	}
+}
+
+func TestStatusLatensiMs(t *testing.T) {
+	s := kbbitest.Mulai(t)
+	s.AturLatensi(20 * time.Millisecond)
+
+	status, err := CekStatus(nil)
+	if err != nil {
+		t.Fatalf("CekStatus: %v", err)
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	var hasil struct {
+		LatensiMs int64 `json:"latensi_ms"`
+	}
+	if err := json.Unmarshal(data, &hasil); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if hasil.LatensiMs < 20 || hasil.LatensiMs != status.Latensi.Milliseconds() {
+		t.Errorf("latensi_ms = %d, latensi = %s", hasil.LatensiMs, status.Latensi)
+	}
+}
+
+func TestLoginUlangParalel(t *testing.T) {
+	s := kbbitest.Mulai(t)
+	autentikasi := masukServer(t, s)
+	s.TambahEntri("rumah", kbbitest.Entri{
+		Nama:        "ru·mah",
+		Makna:       []kbbitest.Makna{{Kelas: "n", Teks: "bangunan untuk tempat tinggal"}},
+		KataTurunan: []string{"berumah"},
+	})
+
+	s.AkhiriSemuaSesi()
+	sebelum := s.JumlahLogin()
+
+	const jumlah = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, jumlah)
+	for i := 0; i < jumlah; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			definisi, err := CariDenganOpsi("rumah", Opsi{Auth: autentikasi})
+			if err == nil && len(definisi.Entri[0].KataTurunan) == 0 {
+				err = errors.New("hasil tidak memuat kata terkait pengguna")
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("CariDenganOpsi: %v", err)
+		}
+	}
+	if n := s.JumlahLogin() - sebelum; n != 1 {
+		t.Errorf("jumlah login ulang = %d, ingin 1", n)
+	}
+
+	// Penghitung harian hanya mencatat pencarian ke KBBI Daring
+	if n := autentikasi.PermintaanHariIni(); n != 0 {
+		t.Errorf("PermintaanHariIni untuk server palsu = %d, ingin 0", n)
+	}
+}
+
+func TestSaatSesiBerakhirBolehLogin(t *testing.T) {
+	s := kbbitest.Mulai(t)
+	awal := masukServer(t, s)
+	if err := awal.SimpanKuki(); err != nil {
+		t.Fatalf("SimpanKuki: %v", err)
+	}
+	s.TambahEntri("rumah", kbbitest.Entri{
+		Nama:  "ru·mah",
+		Makna: []kbbitest.Makna{{Kelas: "n", Teks: "bangunan untuk tempat tinggal"}},
+	})
+
+	// Kuki dimuat tanpa email dan sandi sehingga sesi tidak dapat
+	// dipulihkan sendiri; callback masuk kembali dengan kredensialnya
+	autentikasi, err := LoadAuth(awal.LokasiKuki)
+	if err != nil {
+		t.Fatalf("LoadAuth: %v", err)
+	}
+	var dipanggil int
+	var errLogin error
+	autentikasi.SaatSesiBerakhir = func(err error) {
+		dipanggil++
+		autentikasi.Email, autentikasi.Sandi = "penguji@contoh.id", "rahasia"
+		errLogin = autentikasi.Login()
+	}
+
+	s.AkhiriSemuaSesi()
+	sebelum := s.JumlahLogin()
+
+	selesai := make(chan error, 1)
+	go func() {
+		_, err := CariDenganOpsi("rumah", Opsi{Auth: autentikasi})
+		selesai <- err
+	}()
+	select {
+	case err := <-selesai:
+		if err != nil {
+			t.Fatalf("CariDenganOpsi: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("pencarian macet: SaatSesiBerakhir dipanggil sambil memegang kunci login")
+	}
+
+	if dipanggil != 1 {
+		t.Errorf("SaatSesiBerakhir dipanggil %d kali, ingin 1", dipanggil)
+	}
+	if errLogin != nil {
+		t.Errorf("Login dari SaatSesiBerakhir: %v", errLogin)
+	}
+	if n := s.JumlahLogin() - sebelum; n != 1 {
+		t.Errorf("jumlah login = %d, ingin 1", n)
+	}
+	if !autentikasi.Terautentikasi() {
+		t.Error("sesi seharusnya kembali masuk setelah Login dari callback")
+	}
+}
+
+func TestDebugHTMLTanpaKukiSesi(t *testing.T) {
+	s := kbbitest.Mulai(t)
+	autentikasi := masukServer(t, s)
+	s.TambahEntri("rumah", kbbitest.Entri{
+		Nama:  "ru·mah",
+		Makna: []kbbitest.Makna{{Kelas: "n", Teks: "bangunan untuk tempat tinggal"}},
+	})
+
+	alamat, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	var rahasia []string
+	for _, kuki := range autentikasi.GetClient().Jar.Cookies(alamat) {
+		if kuki.Name == kbbitest.NamaKukiSesi {
+			rahasia = append(rahasia, kuki.Value)
+		}
+	}
+	if len(rahasia) == 0 {
+		t.Fatal("kuki sesi tidak ditemukan setelah masuk")
+	}
+
+	direktori := t.TempDir()
+	opsi := Opsi{Auth: autentikasi, DebugHTML: direktori, ModeDebugHTML: DebugSelalu}
+	if _, err := CariDenganOpsi("rumah", opsi); err != nil {
+		t.Fatalf("CariDenganOpsi: %v", err)
+	}
+
+	daftar, err := filepath.Glob(filepath.Join(direktori, "*.json"))
+	if err != nil || len(daftar) == 0 {
+		t.Fatalf("file debug tidak ditulis: %v", err)
+	}
+	for _, lokasi := range daftar {
+		data, err := os.ReadFile(lokasi)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		for _, nilai := range rahasia {
+			if strings.Contains(string(data), nilai) {
+				t.Errorf("%s memuat nilai kuki sesi", filepath.Base(lokasi))
+			}
+		}
+		if !strings.Contains(string(data), "header_permintaan") {
+			t.Errorf("%s tidak memuat header_permintaan", filepath.Base(lokasi))
+		}
+	}
+}
+
+func TestValidasiSesiHalamanKesalahan(t *testing.T) {
+	s := kbbitest.Mulai(t)
+	autentikasi := masukServer(t, s)
+	if err := autentikasi.SimpanKuki(); err != nil {
+		t.Fatalf("SimpanKuki: %v", err)
+	}
+
+	s.GagalkanBerikutnya(1, 503)
+	if valid, err := autentikasi.ValidasiSesi(); !errors.Is(err, ErrKesalahanSitus) || valid {
+		t.Errorf("503: ValidasiSesi = %v, %v; ingin false, ErrKesalahanSitus", valid, err)
+	}
+
+	s.AturKesalahan(kbbitest.KesalahanSitus)
+	if valid, err := autentikasi.ValidasiSesi(); !errors.Is(err, ErrKesalahanSitus) || valid {
+		t.Errorf("Beranda/Error: ValidasiSesi = %v, %v; ingin false, ErrKesalahanSitus", valid, err)
+	}
+	if info, err := autentikasi.StatusSesi(); err == nil || info.Diperiksa {
+		t.Errorf("Beranda/Error: StatusSesi diperiksa = %v, error = %v; ingin gagal", info.Diperiksa, err)
+	}
+	if err := autentikasi.Keluar(); err == nil {
+		t.Error("Beranda/Error: Keluar berhasil, ingin error")
+	}
+	if _, err := os.Stat(autentikasi.LokasiKuki); err != nil {
+		t.Errorf("file kuki terhapus walaupun keluar gagal: %v", err)
+	}
+	if !autentikasi.Terautentikasi() {
+		t.Error("halaman kesalahan mengubah status sesi menjadi keluar")
+	}
+
+	s.AturKesalahan(kbbitest.TanpaKesalahan)
+	if valid, err := autentikasi.ValidasiSesi(); err != nil || !valid {
+		t.Errorf("normal: ValidasiSesi = %v, %v; ingin true", valid, err)
+	}
+
+	s.AkhiriSemuaSesi()
+	s.GagalkanBerikutnya(1, 500)
+	if valid, err := autentikasi.ValidasiSesi(); err == nil || valid {
+		t.Errorf("500 setelah sesi berakhir: ValidasiSesi = %v, %v; ingin error", valid, err)
+	}
+	if valid, err := autentikasi.ValidasiSesi(); err != nil || valid {
+		t.Errorf("sesi berakhir: ValidasiSesi = %v, %v; ingin false", valid, err)
+	}
+}
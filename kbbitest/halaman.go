@@ -0,0 +1,163 @@
+package kbbitest
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+)
+
+// namaKelas berisi keterangan kelas kata yang umum, dipakai ketika
+// Makna.NamaKelas kosong
+var namaKelas = map[string]string{
+	"n":    "Nomina: kata benda",
+	"v":    "Verba: kata kerja",
+	"a":    "Adjektiva: kata yang menjelaskan nomina atau pronomina",
+	"adv":  "Adverbia: kata yang menjelaskan verba, adjektiva, adverbia lain, atau kalimat",
+	"num":  "Numeralia: kata bilangan",
+	"p":    "Partikel: kelas kata yang meliputi kata depan, kata sambung, kata seru, kata sandang, ucapan salam",
+	"pron": "Pronomina: kata ganti",
+}
+
+// renderHalaman merender kerangka halaman KBBI
+func renderHalaman(judul, navigasi, isi string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>%s - KBBI Daring</title></head>
+<body>
+<nav class="navbar">%s</nav>
+<div class="container body-content">
+%s
+</div>
+<footer><p>Badan Pengembangan dan Pembinaan Bahasa</p></footer>
+</body>
+</html>`, html.EscapeString(judul), navigasi, isi)
+}
+
+// renderNavigasiUmum merender navigasi untuk pengguna yang belum masuk
+func renderNavigasiUmum() string {
+	return `<ul class="nav navbar-nav navbar-right"><li><a id="loginLink" href="/Account/Login">Masuk</a></li></ul>`
+}
+
+// renderNavigasiMasuk merender navigasi beserta form keluar untuk pengguna
+// yang sudah masuk
+func renderNavigasiMasuk(token string) string {
+	return fmt.Sprintf(`<form action="/Account/LogOff" class="navbar-right" id="logoutForm" method="post"><input name="__RequestVerificationToken" type="hidden" value="%s" /><ul class="nav navbar-nav navbar-right"><li><a href="javascript:document.getElementById('logoutForm').submit()">Keluar</a></li></ul></form>`,
+		html.EscapeString(token))
+}
+
+// renderFormLogin merender form login beserta pesan validasi
+func renderFormLogin(token string, pesan []string) string {
+	var sb strings.Builder
+	sb.WriteString(`<h2>Masuk</h2>`)
+	sb.WriteString(`<form action="/Account/Login" method="post">`)
+	sb.WriteString(fmt.Sprintf(`<input name="__RequestVerificationToken" type="hidden" value="%s" />`, html.EscapeString(token)))
+	if len(pesan) > 0 {
+		sb.WriteString(`<div class="validation-summary-errors text-danger"><ul>`)
+		for _, p := range pesan {
+			sb.WriteString(fmt.Sprintf(`<li>%s</li>`, html.EscapeString(p)))
+		}
+		sb.WriteString(`</ul></div>`)
+	}
+	sb.WriteString(`<input name="Posel" type="email" value="" />`)
+	sb.WriteString(`<input name="KataSandi" type="password" />`)
+	sb.WriteString(`<input name="IngatSaya" type="checkbox" value="true" /><input name="IngatSaya" type="hidden" value="false" />`)
+	sb.WriteString(`<input type="submit" value="Masuk" />`)
+	sb.WriteString(`</form>`)
+	return sb.String()
+}
+
+// renderTidakDitemukan merender halaman entri tidak ditemukan beserta saran
+func renderTidakDitemukan(saran []string) string {
+	var sb strings.Builder
+	sb.WriteString(`<h4 style="color:red">Entri tidak ditemukan.</h4>`)
+	if len(saran) > 0 {
+		sb.WriteString(`<p>Berikut beberapa saran entri lain yang mirip.</p><div class="row">`)
+		for _, kata := range saran {
+			sb.WriteString(fmt.Sprintf(`<div class="col-md-3"><a href="/entri/%s">%s</a></div>`,
+				url.PathEscape(kata), html.EscapeString(kata)))
+		}
+		sb.WriteString(`</div>`)
+	}
+	return sb.String()
+}
+
+// renderEntri merender daftar entri dengan tata letak halaman KBBI; kata
+// terkait hanya dirender untuk pengguna yang sudah masuk
+func renderEntri(daftar []Entri, masuk bool) string {
+	var sb strings.Builder
+	sb.WriteString(`<hr style="margin:0 0 10px" />`)
+
+	for _, entri := range daftar {
+		sb.WriteString(`<h2 style="margin-bottom:3px">`)
+		sb.WriteString(html.EscapeString(entri.Nama))
+		if entri.Nomor != "" {
+			sb.WriteString(fmt.Sprintf(`<sup>%s</sup>`, html.EscapeString(entri.Nomor)))
+		}
+		if entri.Lafal != "" {
+			sb.WriteString(fmt.Sprintf(` <span class="syllable">%s</span>`, html.EscapeString(entri.Lafal)))
+		}
+		sb.WriteString(`</h2>`)
+
+		sb.WriteString(`<ol>`)
+		for _, makna := range entri.Makna {
+			sb.WriteString(renderMakna(makna))
+		}
+		sb.WriteString(`</ol>`)
+
+		if masuk {
+			sb.WriteString(renderTerkait("Kata Turunan", entri.KataTurunan))
+			sb.WriteString(renderTerkait("Gabungan Kata", entri.GabunganKata))
+			sb.WriteString(renderTerkait("Peribahasa", entri.Peribahasa))
+			sb.WriteString(renderTerkait("Idiom", entri.Idiom))
+		}
+	}
+
+	sb.WriteString(`<hr />`)
+	return sb.String()
+}
+
+// renderMakna merender satu makna sebagai elemen li
+func renderMakna(makna Makna) string {
+	var sb strings.Builder
+	sb.WriteString(`<li>`)
+	if makna.Kelas != "" {
+		judul := makna.NamaKelas
+		if judul == "" {
+			judul = namaKelas[makna.Kelas]
+		}
+		if judul == "" {
+			judul = makna.Kelas
+		}
+		sb.WriteString(fmt.Sprintf(`<font color="red"><i><span title="%s">%s</span></i></font> `,
+			html.EscapeString(judul), html.EscapeString(makna.Kelas)))
+	}
+	sb.WriteString(html.EscapeString(makna.Teks))
+	if len(makna.Contoh) > 0 {
+		sb.WriteString(fmt.Sprintf(`: <font color="grey"><i>%s</i></font>`, html.EscapeString(strings.Join(makna.Contoh, "; "))))
+	}
+	if len(makna.Anak) > 0 {
+		sb.WriteString(`<ol type="a">`)
+		for _, anak := range makna.Anak {
+			sb.WriteString(renderMakna(anak))
+		}
+		sb.WriteString(`</ol>`)
+	}
+	sb.WriteString(`</li>`)
+	return sb.String()
+}
+
+// renderTerkait merender bagian kata terkait dengan judul h4
+func renderTerkait(judul string, kata []string) string {
+	if len(kata) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<h4>%s</h4><ul class="list-inline">`, html.EscapeString(judul)))
+	for _, k := range kata {
+		sb.WriteString(fmt.Sprintf(`<li><a href="/entri/%s">%s</a></li>`, url.PathEscape(k), html.EscapeString(k)))
+	}
+	sb.WriteString(`</ul>`)
+	return sb.String()
+}
@@ -0,0 +1,558 @@
+// Package kbbitest menyediakan server KBBI Daring palsu berbasis httptest
+// untuk menguji kode yang memakai GoKBBI tanpa menghubungi situs sebenarnya
+//
+// Server melayani halaman entri, halaman saran, Cari/Hasil, alur login dan
+// keluar dengan token CSRF, serta pengalihan kesalahan (BatasSehari,
+// ModaTerbatas, Account/Banned, Beranda/Error). Latensi dan kegagalan dapat
+// disuntikkan untuk menguji percobaan ulang dan penanganan error.
+//
+// Contoh penggunaan dalam pengujian:
+//
+//	func TestCari(t *testing.T) {
+//		server := kbbitest.Mulai(t)
+//		server.TambahEntri("rumah", kbbitest.Entri{
+//			Nama:  "ru·mah",
+//			Makna: []kbbitest.Makna{{Kelas: "n", Teks: "bangunan untuk tempat tinggal"}},
+//		})
+//
+//		definisi, err := gokbbi.CariDenganOpsi("rumah", gokbbi.Opsi{})
+//		if err != nil {
+//			t.Fatal(err)
+//		}
+//		if len(definisi.Entri) != 1 {
+//			t.Fatalf("jumlah entri = %d", len(definisi.Entri))
+//		}
+//	}
+//
+// Pengarahan library bersifat global untuk seluruh proses, sehingga pengujian
+// yang memakai Mulai atau Arahkan tidak boleh berjalan paralel dengan
+// pengujian lain yang mengarah ke server berbeda.
+package kbbitest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/situs"
+)
+
+const (
+	// NamaKukiSesi adalah nama kuki sesi yang diberikan setelah login
+	NamaKukiSesi = ".AspNet.ApplicationCookie"
+
+	// NamaKukiToken adalah nama kuki pasangan token CSRF
+	NamaKukiToken = "__RequestVerificationToken"
+)
+
+// Kesalahan adalah halaman kesalahan yang dituju oleh setiap permintaan
+// entri ketika diaktifkan dengan AturKesalahan
+type Kesalahan int
+
+const (
+	// TanpaKesalahan melayani halaman entri seperti biasa
+	TanpaKesalahan Kesalahan = iota
+
+	// BatasSehari mengalihkan ke Beranda/BatasSehari
+	BatasSehari
+
+	// ModaTerbatas mengalihkan ke Beranda/ModaTerbatas
+	ModaTerbatas
+
+	// AkunDibekukan mengalihkan ke Account/Banned
+	AkunDibekukan
+
+	// KesalahanSitus mengalihkan ke Beranda/Error
+	KesalahanSitus
+)
+
+// String mengembalikan nama kesalahan
+func (k Kesalahan) String() string {
+	switch k {
+	case BatasSehari:
+		return "BatasSehari"
+	case ModaTerbatas:
+		return "ModaTerbatas"
+	case AkunDibekukan:
+		return "AkunDibekukan"
+	case KesalahanSitus:
+		return "KesalahanSitus"
+	default:
+		return "TanpaKesalahan"
+	}
+}
+
+// lokasi mengembalikan lokasi halaman kesalahan
+func (k Kesalahan) lokasi() string {
+	switch k {
+	case BatasSehari:
+		return "/Beranda/BatasSehari"
+	case ModaTerbatas:
+		return "/Beranda/ModaTerbatas"
+	case AkunDibekukan:
+		return "/Account/Banned"
+	case KesalahanSitus:
+		return "/Beranda/Error"
+	default:
+		return ""
+	}
+}
+
+// Makna adalah satu makna entri
+type Makna struct {
+	// Kelas adalah kode kelas kata, misalnya "n" atau "v"
+	Kelas string
+
+	// NamaKelas adalah keterangan kelas kata; kosong berarti diambil dari
+	// daftar kelas kata yang umum
+	NamaKelas string
+
+	// Teks adalah isi makna
+	Teks string
+
+	// Contoh berisi contoh pemakaian, dengan "--" sebagai pengganti entri
+	Contoh []string
+
+	// Anak berisi makna anak yang dirender sebagai daftar a, b, c
+	Anak []Makna
+}
+
+// Entri adalah data satu entri yang dirender menjadi halaman KBBI
+type Entri struct {
+	// Nama adalah nama entri beserta pemisah suku kata, misalnya "ru·mah";
+	// kosong berarti memakai kata yang didaftarkan
+	Nama string
+
+	// Nomor adalah nomor homonim, kosong jika entri tidak berhomonim
+	Nomor string
+
+	// Lafal adalah pelafalan entri, misalnya "/rumah/"
+	Lafal string
+
+	// Makna berisi makna-makna entri
+	Makna []Makna
+
+	// KataTurunan, GabunganKata, Peribahasa, dan Idiom hanya ditampilkan
+	// untuk pengguna masuk. Makna peribahasa dan idiom tidak ditulis pada
+	// halaman entri; daftarkan sebagai entri tersendiri bila diperlukan.
+	KataTurunan  []string
+	GabunganKata []string
+	Peribahasa   []string
+	Idiom        []string
+}
+
+// akun adalah akun terdaftar pada server palsu
+type akun struct {
+	sandi     string
+	dibekukan bool
+}
+
+// Server adalah server KBBI Daring palsu
+//
+// Semua metode aman dipanggil bersamaan dengan permintaan yang sedang
+// dilayani.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	entri      map[string][]Entri
+	halaman    map[string]string
+	saran      map[string][]string
+	akun       map[string]*akun
+	sesi       map[string]string
+	token      map[string]bool
+	kesalahan  Kesalahan
+	latensi    time.Duration
+	gagal      int
+	kodeGagal  int
+	permintaan int
+	login      int
+}
+
+// BaruServer membuat dan menjalankan server KBBI palsu
+//
+// Server belum mengarahkan library; panggil Arahkan, atau gunakan Mulai
+// dalam pengujian. Tutup server dengan Close setelah selesai.
+func BaruServer() *Server {
+	s := &Server{
+		entri:   make(map[string][]Entri),
+		halaman: make(map[string]string),
+		saran:   make(map[string][]string),
+		akun:    make(map[string]*akun),
+		sesi:    make(map[string]string),
+		token:   make(map[string]bool),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.layani))
+	return s
+}
+
+// Mulai membuat server, mengarahkan library ke server tersebut, dan
+// mendaftarkan pembersihan pada akhir pengujian
+func Mulai(t testing.TB) *Server {
+	t.Helper()
+
+	s := BaruServer()
+	pulihkan := s.Arahkan()
+	t.Cleanup(func() {
+		pulihkan()
+		s.Close()
+	})
+	return s
+}
+
+// Arahkan mengarahkan library ke server ini tanpa jeda antarpermintaan dan
+// mengembalikan fungsi untuk memulihkan pengaturan sebelumnya
+//
+// Cache dilewati selama library mengarah ke host selain KBBI Daring.
+func (s *Server) Arahkan() (pulihkan func()) {
+	return situs.Atur(situs.Pengaturan{Host: s.URL})
+}
+
+// TambahEntri mendaftarkan entri untuk kata; beberapa entri menjadi
+// homonim pada halaman yang sama
+func (s *Server) TambahEntri(kata string, entri ...Entri) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range entri {
+		if e.Nama == "" {
+			e.Nama = kata
+		}
+		s.entri[kata] = append(s.entri[kata], e)
+	}
+}
+
+// TambahHalaman mendaftarkan HTML mentah untuk kata, misalnya halaman
+// KBBI yang direkam; halaman ini menggantikan entri yang didaftarkan
+func (s *Server) TambahHalaman(kata, html string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.halaman[kata] = html
+}
+
+// TambahSaran mendaftarkan saran entri untuk kata yang tidak ditemukan
+func (s *Server) TambahSaran(kata string, saran ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.saran[kata] = append(s.saran[kata], saran...)
+}
+
+// TambahAkun mendaftarkan akun yang dapat dipakai untuk login
+func (s *Server) TambahAkun(email, sandi string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.akun[email] = &akun{sandi: sandi}
+}
+
+// BekukanAkun membekukan akun: login dan permintaan dari sesinya
+// dialihkan ke Account/Banned
+func (s *Server) BekukanAkun(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, ada := s.akun[email]; ada {
+		a.dibekukan = true
+	}
+}
+
+// AkhiriSemuaSesi mengakhiri semua sesi di sisi server, seperti sesi
+// KBBI yang kedaluwarsa
+func (s *Server) AkhiriSemuaSesi() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sesi = make(map[string]string)
+}
+
+// AturKesalahan mengalihkan setiap permintaan entri ke halaman kesalahan,
+// termasuk beranda untuk KesalahanSitus; TanpaKesalahan mengembalikan
+// perilaku normal
+func (s *Server) AturKesalahan(k Kesalahan) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.kesalahan = k
+}
+
+// AturLatensi menambahkan jeda sebelum setiap respons
+func (s *Server) AturLatensi(latensi time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latensi = latensi
+}
+
+// GagalkanBerikutnya membuat n permintaan berikutnya dijawab dengan kode
+// status yang diberikan, misalnya http.StatusServiceUnavailable
+func (s *Server) GagalkanBerikutnya(n, kodeStatus int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gagal = n
+	s.kodeGagal = kodeStatus
+}
+
+// JumlahPermintaan mengembalikan jumlah permintaan yang diterima server
+func (s *Server) JumlahPermintaan() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.permintaan
+}
+
+// JumlahLogin mengembalikan jumlah percobaan login yang diterima server
+func (s *Server) JumlahLogin() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.login
+}
+
+// layani menangani semua permintaan ke server palsu
+func (s *Server) layani(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.permintaan++
+	latensi := s.latensi
+	gagal := s.gagal > 0
+	kodeGagal := s.kodeGagal
+	if gagal {
+		s.gagal--
+	}
+	s.mu.Unlock()
+
+	if latensi > 0 {
+		select {
+		case <-time.After(latensi):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if gagal {
+		http.Error(w, http.StatusText(kodeGagal), kodeGagal)
+		return
+	}
+
+	switch lokasi := strings.TrimSuffix(r.URL.Path, "/"); {
+	case lokasi == "" || lokasi == "/Beranda":
+		s.layaniBeranda(w, r)
+	case strings.HasPrefix(lokasi, "/entri/"):
+		s.layaniEntri(w, r, strings.TrimPrefix(lokasi, "/entri/"))
+	case lokasi == "/Cari/Hasil":
+		s.layaniEntri(w, r, r.URL.Query().Get("frasa"))
+	case lokasi == "/Account/Login":
+		s.layaniLogin(w, r)
+	case lokasi == "/Account/LogOff":
+		s.layaniKeluar(w, r)
+	case lokasi == "/Beranda/BatasSehari":
+		s.tulis(w, r, http.StatusOK, "Batas Sehari", "<p>Anda telah mencapai batas pencarian harian.</p>")
+	case lokasi == "/Beranda/ModaTerbatas":
+		s.tulis(w, r, http.StatusOK, "Moda Terbatas", "<p>Moda terbatas sedang diaktifkan.</p>")
+	case lokasi == "/Account/Banned":
+		s.tulis(w, r, http.StatusOK, "Akun Dibekukan", "<p>Akun Anda dibekukan.</p>")
+	case lokasi == "/Beranda/Error":
+		s.tulis(w, r, http.StatusOK, "Kesalahan", "<p>Terjadi kesalahan saat memproses permintaan.</p>")
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// layaniBeranda melayani halaman beranda
+func (s *Server) layaniBeranda(w http.ResponseWriter, r *http.Request) {
+	if s.alihkanAkunDibekukan(w, r) {
+		return
+	}
+
+	s.mu.Lock()
+	kesalahan := s.kesalahan
+	s.mu.Unlock()
+
+	// Hanya kesalahan situs yang juga mengalihkan beranda
+	if kesalahan == KesalahanSitus {
+		http.Redirect(w, r, kesalahan.lokasi(), http.StatusFound)
+		return
+	}
+	s.tulis(w, r, http.StatusOK, "Beranda", "<p>KBBI Daring</p>")
+}
+
+// layaniEntri melayani halaman entri, saran, atau kesalahan untuk kata
+func (s *Server) layaniEntri(w http.ResponseWriter, r *http.Request, kata string) {
+	if s.alihkanAkunDibekukan(w, r) {
+		return
+	}
+
+	s.mu.Lock()
+	kesalahan := s.kesalahan
+	halaman, adaHalaman := s.halaman[kata]
+	entri := s.entri[kata]
+	saran := s.saran[kata]
+	s.mu.Unlock()
+
+	if lokasi := kesalahan.lokasi(); lokasi != "" {
+		http.Redirect(w, r, lokasi, http.StatusFound)
+		return
+	}
+
+	if adaHalaman {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(halaman))
+		return
+	}
+
+	if len(entri) == 0 {
+		s.tulis(w, r, http.StatusOK, kata, renderTidakDitemukan(saran))
+		return
+	}
+
+	s.tulis(w, r, http.StatusOK, kata, renderEntri(entri, s.emailSesi(r) != ""))
+}
+
+// layaniLogin melayani form login dan memproses login
+func (s *Server) layaniLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		token := s.buatToken(w)
+		s.tulis(w, r, http.StatusOK, "Masuk", renderFormLogin(token, nil))
+		return
+	}
+
+	s.mu.Lock()
+	s.login++
+	s.mu.Unlock()
+
+	if !s.tokenValid(r) {
+		http.Redirect(w, r, "/Beranda/Error", http.StatusFound)
+		return
+	}
+
+	email := r.PostFormValue("Posel")
+	sandi := r.PostFormValue("KataSandi")
+
+	s.mu.Lock()
+	a, ada := s.akun[email]
+	s.mu.Unlock()
+
+	switch {
+	case !ada || a.sandi != sandi:
+		token := s.buatToken(w)
+		s.tulis(w, r, http.StatusOK, "Masuk", renderFormLogin(token, []string{"Alamat posel atau kata sandi salah."}))
+		return
+	case a.dibekukan:
+		http.Redirect(w, r, "/Account/Banned", http.StatusFound)
+		return
+	}
+
+	idSesi := acak()
+	s.mu.Lock()
+	s.sesi[idSesi] = email
+	s.mu.Unlock()
+
+	kuki := &http.Cookie{Name: NamaKukiSesi, Value: idSesi, Path: "/", HttpOnly: true}
+	if r.PostFormValue("IngatSaya") == "true" {
+		kuki.Expires = time.Now().Add(14 * 24 * time.Hour)
+	}
+	http.SetCookie(w, kuki)
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// layaniKeluar mengakhiri sesi setelah memeriksa token CSRF
+func (s *Server) layaniKeluar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !s.tokenValid(r) {
+		http.Redirect(w, r, "/Beranda/Error", http.StatusFound)
+		return
+	}
+
+	if kuki, err := r.Cookie(NamaKukiSesi); err == nil {
+		s.mu.Lock()
+		delete(s.sesi, kuki.Value)
+		s.mu.Unlock()
+	}
+
+	http.SetCookie(w, &http.Cookie{Name: NamaKukiSesi, Value: "", Path: "/", MaxAge: -1})
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// alihkanAkunDibekukan mengalihkan permintaan dari sesi akun yang
+// dibekukan ke Account/Banned
+func (s *Server) alihkanAkunDibekukan(w http.ResponseWriter, r *http.Request) bool {
+	email := s.emailSesi(r)
+	if email == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	a := s.akun[email]
+	dibekukan := a != nil && a.dibekukan
+	s.mu.Unlock()
+
+	if dibekukan {
+		http.Redirect(w, r, "/Account/Banned", http.StatusFound)
+	}
+	return dibekukan
+}
+
+// emailSesi mengembalikan email pemilik sesi permintaan, atau string
+// kosong jika permintaan tidak membawa sesi yang berlaku
+func (s *Server) emailSesi(r *http.Request) string {
+	kuki, err := r.Cookie(NamaKukiSesi)
+	if err != nil {
+		return ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sesi[kuki.Value]
+}
+
+// tulis menulis halaman lengkap dengan navigasi sesuai status sesi
+func (s *Server) tulis(w http.ResponseWriter, r *http.Request, kode int, judul, isi string) {
+	navigasi := renderNavigasiUmum()
+	if s.emailSesi(r) != "" {
+		navigasi = renderNavigasiMasuk(s.buatToken(w))
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(kode)
+	w.Write([]byte(renderHalaman(judul, navigasi, isi)))
+}
+
+// buatToken membuat token CSRF baru beserta kuki pasangannya
+func (s *Server) buatToken(w http.ResponseWriter) string {
+	token := acak()
+
+	s.mu.Lock()
+	s.token[token] = true
+	s.mu.Unlock()
+
+	http.SetCookie(w, &http.Cookie{Name: NamaKukiToken, Value: token, Path: "/", HttpOnly: true})
+	return token
+}
+
+// tokenValid memeriksa token CSRF pada form terhadap kuki pasangannya
+func (s *Server) tokenValid(r *http.Request) bool {
+	token := r.PostFormValue("__RequestVerificationToken")
+	kuki, err := r.Cookie(NamaKukiToken)
+	if err != nil || token == "" || kuki.Value != token {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token[token]
+}
+
+// acak membuat string heksadesimal acak untuk token dan sesi
+func acak() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,143 @@
+// Package parse mengurai halaman HTML KBBI Daring tanpa melakukan permintaan
+// jaringan
+//
+// Paket ini berguna untuk memproses halaman yang sudah tersimpan, misalnya
+// arsip hasil unduhan atau file debug dari --debug-html, tanpa memerlukan
+// fetcher maupun autentikasi. Hasilnya bertipe sama dengan gokbbi.Definisi.
+//
+// Setiap fungsi juga mengembalikan daftar Diagnostik untuk bagian halaman
+// yang tidak dikenali. Dengan Opsi.Ketat, diagnostik TingkatStruktur menjadi
+// error sehingga perubahan markup KBBI langsung terlihat.
+//
+// Contoh penggunaan:
+//
+//	f, err := os.Open("rumah.html")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer f.Close()
+//
+//	definisi, diagnostik, err := parse.DariReader(f, parse.Opsi{Kata: "rumah"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, d := range diagnostik {
+//		log.Println("peringatan:", d)
+//	}
+//	fmt.Println(definisi.String())
+package parse
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/model"
+	"github.com/ZulfaNurhuda/GoKBBI.project/internal/parser"
+)
+
+// Definisi adalah struktur data hasil parsing, identik dengan gokbbi.Definisi
+type Definisi = model.Definisi
+
+// Tampilan menentukan tata letak halaman yang diurai
+type Tampilan = parser.Tampilan
+
+// Tata letak halaman yang didukung
+const (
+	// TampilanOtomatis mendeteksi tata letak dari ada tidaknya tautan masuk
+	TampilanOtomatis = parser.TampilanOtomatis
+
+	// TampilanUmum untuk halaman yang dibuka tanpa login
+	TampilanUmum = parser.TampilanUmum
+
+	// TampilanPengguna untuk halaman pengguna terdaftar, termasuk etimologi
+	// dan kata terkait
+	TampilanPengguna = parser.TampilanPengguna
+)
+
+// Diagnostik adalah peringatan tentang bagian halaman yang tidak dapat
+// diurai, berisi nama entri, konteks selektor, dan cuplikan HTML
+type Diagnostik = parser.Diagnostik
+
+// TingkatDiagnostik menyatakan keseriusan diagnostik
+type TingkatDiagnostik = parser.TingkatDiagnostik
+
+// Tingkat diagnostik; hanya TingkatStruktur yang menjadi error dalam mode
+// ketat
+const (
+	TingkatInfo       = parser.TingkatInfo
+	TingkatPeringatan = parser.TingkatPeringatan
+	TingkatStruktur   = parser.TingkatStruktur
+)
+
+// KesalahanParsing dikembalikan dalam mode ketat dan berisi semua diagnostik
+type KesalahanParsing = parser.KesalahanParsing
+
+var (
+	// ErrTataLetak cocok dengan setiap kesalahan mode ketat
+	ErrTataLetak = parser.ErrTataLetak
+
+	// ErrTanpaEntri cocok dengan kesalahan mode ketat untuk halaman yang
+	// tidak berisi entri maupun saran entri
+	ErrTanpaEntri = parser.ErrTanpaEntri
+)
+
+// Opsi adalah pengaturan parsing
+type Opsi struct {
+	// Tampilan menentukan tata letak halaman, default TampilanOtomatis
+	Tampilan Tampilan
+
+	// Ketat mengubah diagnostik TingkatStruktur, termasuk halaman tanpa
+	// entri maupun saran entri, menjadi *KesalahanParsing; label atau kelas
+	// kata yang tidak dikenal tidak menggagalkan parsing
+	Ketat bool
+
+	// TanpaLampiran melewati bagian lampiran alih-alih mengisinya ke
+	// Definisi.Lampiran
+	TanpaLampiran bool
+
+	// Kata yang dicari, dipakai untuk mengisi Definisi.Pranala; kosongkan
+	// jika pranala tidak diperlukan
+	Kata string
+}
+
+// DariReader mengurai halaman KBBI dari reader
+//
+// Parameter:
+//   - r: sumber HTML halaman KBBI
+//   - opsi: pengaturan parsing
+//
+// Return:
+//   - *Definisi: hasil parsing berisi entri, makna, dll; tetap diisi
+//     bersama *KesalahanParsing dalam mode ketat
+//   - []Diagnostik: peringatan untuk bagian halaman yang tidak dikenali
+//   - error: error jika HTML tidak dapat dibaca atau, dalam mode ketat,
+//     ada diagnostik
+func DariReader(r io.Reader, opsi Opsi) (*Definisi, []Diagnostik, error) {
+	definisi, diagnostik, err := parser.ParseDefinisiDari(r, parser.Opsi{
+		Tampilan:      opsi.Tampilan,
+		Ketat:         opsi.Ketat,
+		TanpaLampiran: opsi.TanpaLampiran,
+	})
+	if definisi != nil && opsi.Kata != "" {
+		parser.SetPranala(definisi, opsi.Kata)
+	}
+	return definisi, diagnostik, err
+}
+
+// DariBytes mengurai halaman KBBI dari byte HTML
+func DariBytes(data []byte, opsi Opsi) (*Definisi, []Diagnostik, error) {
+	return DariReader(bytes.NewReader(data), opsi)
+}
+
+// DariFile mengurai halaman KBBI yang tersimpan di file
+func DariFile(lokasi string, opsi Opsi) (*Definisi, []Diagnostik, error) {
+	f, err := os.Open(lokasi)
+	if err != nil {
+		return nil, nil, fmt.Errorf("gagal membuka file HTML: %w", err)
+	}
+	defer f.Close()
+
+	return DariReader(f, opsi)
+}